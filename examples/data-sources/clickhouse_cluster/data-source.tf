@@ -0,0 +1,16 @@
+data "clickhouseops_cluster" "analytics" {
+  name = "analytics"
+}
+
+resource "clickhouseops_distributed" "events" {
+  database_name = "analytics"
+  name          = "events"
+  columns = [{
+    name = "id"
+    type = "UInt64"
+  }]
+  dist_cluster      = data.clickhouseops_cluster.analytics.name
+  dist_database     = "analytics"
+  dist_table        = "events_local"
+  dist_sharding_key = data.clickhouseops_cluster.analytics.shard_count > 1 ? "rand()" : null
+}
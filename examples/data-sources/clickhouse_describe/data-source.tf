@@ -0,0 +1,13 @@
+data "clickhouseops_describe" "users" {
+  function              = "postgresql"
+  named_collection_name = "crm_postgres"
+  parameters = {
+    schema = "public"
+    table  = "users"
+  }
+}
+
+data "clickhouseops_describe" "events" {
+  function  = "url"
+  arguments = ["https://example.com/events.parquet", "Parquet"]
+}
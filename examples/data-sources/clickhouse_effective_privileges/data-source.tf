@@ -0,0 +1,13 @@
+data "clickhouseops_effective_privileges" "etl" {
+  user_name     = "etl"
+  privilege     = "INSERT"
+  database_name = "analytics"
+  table_name    = "events"
+}
+
+check "etl_can_insert_events" {
+  assert {
+    condition     = data.clickhouseops_effective_privileges.etl.allowed
+    error_message = "User etl cannot INSERT into analytics.events"
+  }
+}
@@ -0,0 +1,4 @@
+data "clickhouseops_grants" "analytics" {
+  assignee      = "readonly"
+  database_name = "analytics"
+}
@@ -0,0 +1,3 @@
+data "clickhouseops_role_grants" "readonly_members" {
+  role_name = "readonly"
+}
@@ -0,0 +1,3 @@
+data "clickhouseops_roles" "readonly" {
+  name = "readonly"
+}
@@ -0,0 +1,12 @@
+data "clickhouseops_s3describe" "addresses" {
+  named_collection_name  = "s3_landing"
+  path                   = "https://bucket.s3.amazonaws.com/addresses/*.csv.gz"
+  format                 = "CSVWithNames"
+  schema_inference_hints = "zip_code String, created_at DateTime"
+  settings = {
+    input_format_max_rows_to_read_for_schema_inference = "10000"
+    input_format_try_infer_dates                       = "1"
+  }
+  strip_nullable          = true
+  low_cardinality_strings = true
+}
@@ -0,0 +1,14 @@
+data "clickhouseops_server_info" "current" {}
+
+resource "clickhouseops_s3queue" "landing" {
+  count         = data.clickhouseops_server_info.current.features["s3queue"] ? 1 : 0
+  name          = "landing_queue"
+  database_name = "raw"
+  columns = [{
+    name = "line"
+    type = "String"
+  }]
+  path   = "https://bucket.s3.amazonaws.com/landing/*.csv"
+  format = "CSV"
+  nosign = true
+}
@@ -0,0 +1,13 @@
+data "clickhouseops_table_schema" "events_local" {
+  database_name = "analytics"
+  table_name    = "events_local"
+}
+
+resource "clickhouseops_distributed" "events" {
+  database_name = "analytics"
+  name          = "events"
+  columns       = [for column in data.clickhouseops_table_schema.events_local.columns : { name = column.name, type = column.type }]
+  dist_cluster  = "default"
+  dist_database = "analytics"
+  dist_table    = "events_local"
+}
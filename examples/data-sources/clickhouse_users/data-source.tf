@@ -0,0 +1,9 @@
+data "clickhouseops_users" "ldap_analysts" {
+  pattern = "analyst_%"
+}
+
+resource "clickhouseops_grantselect" "analysts" {
+  for_each      = toset([for user in data.clickhouseops_users.ldap_analysts.users : user.name])
+  database_name = "analytics"
+  assignee      = each.value
+}
@@ -1,6 +1,15 @@
+ephemeral "aws_secretsmanager_secret_version" "postgres" {
+  secret_id = "clickhouse/postgres"
+}
+
 provider "clickhouseops" {
   host     = "localhost"
   port     = 9000
   username = "default"
   password = ""
+
+  # Referenced by name from *_secret attributes, values are not stored in state
+  secrets = {
+    postgres_password = ephemeral.aws_secretsmanager_secret_version.postgres.secret_string
+  }
 }
@@ -0,0 +1,49 @@
+resource "clickhouseops_database" "test" {
+  name = "test"
+}
+
+resource "clickhouseops_namedcollection" "postgres" {
+  name = "postgres"
+  keyvaluepairs = [{
+    key   = "host"
+    value = "postgres"
+    }, {
+    key   = "port"
+    value = "5432"
+    }, {
+    key   = "user"
+    value = "user"
+    }, {
+    key   = "db"
+    value = "postgres"
+  }]
+  sensitive_keyvaluepairs = [{
+    key   = "password"
+    value = "password"
+  }]
+}
+
+resource "clickhouseops_dictionary" "countries" {
+  name          = "countries"
+  database_name = clickhouseops_database.test.name
+  attributes = [{
+    name = "id"
+    type = "UInt64"
+    }, {
+    name    = "name"
+    type    = "String"
+    default = "''"
+  }]
+  primary_key = ["id"]
+  source = {
+    type                  = "POSTGRESQL"
+    named_collection_name = clickhouseops_namedcollection.postgres.name
+    parameters = [{
+      name  = "table"
+      value = "countries"
+    }]
+  }
+  layout       = "HASHED"
+  lifetime_min = 0
+  lifetime_max = 300
+}
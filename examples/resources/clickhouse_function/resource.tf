@@ -0,0 +1,15 @@
+resource "clickhouseops_database" "test" {
+  name = "test"
+}
+
+resource "clickhouseops_function" "parse_level" {
+  name       = "parse_level"
+  parameters = ["message"]
+  expression = "upper(extract(message, '^\\[(\\w+)\\]'))"
+}
+
+resource "clickhouseops_view" "levels" {
+  name          = "levels"
+  database_name = clickhouseops_database.test.name
+  sql           = "SELECT ${clickhouseops_function.parse_level.name}(message) AS level FROM system.text_log"
+}
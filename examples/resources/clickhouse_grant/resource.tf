@@ -0,0 +1,32 @@
+resource "clickhouseops_simpleuser" "ingestion" {
+  name            = "ingestion"
+  sha256_password = sha256("dummy_password")
+}
+
+# INSERT only access to a single table
+resource "clickhouseops_grant" "ingestion_events" {
+  database_name = "analytics"
+  table_name    = "events"
+  privileges = [{
+    privilege = "INSERT"
+  }]
+  assignee = clickhouseops_simpleuser.ingestion.name
+}
+
+# Global privileges are granted on *.*
+resource "clickhouseops_grant" "ingestion_sources" {
+  privileges = [{
+    privilege = "S3"
+    }, {
+    privilege = "KAFKA"
+  }]
+  assignee = clickhouseops_simpleuser.ingestion.name
+}
+
+resource "clickhouseops_grant" "ingestion_collection" {
+  named_collection = "kafka_events"
+  privileges = [{
+    privilege = "NAMED COLLECTION"
+  }]
+  assignee = clickhouseops_simpleuser.ingestion.name
+}
@@ -0,0 +1,19 @@
+resource "clickhouseops_simpleuser" "alice" {
+  name            = "alice"
+  sha256_password = sha256("dummy_password")
+}
+
+resource "clickhouseops_simplerole" "reader" {
+  name = "reader"
+}
+
+resource "clickhouseops_simplerole" "writer" {
+  name = "writer"
+}
+
+# Grant both roles to the user and make them default roles
+resource "clickhouseops_grantrole" "alice" {
+  role_names  = [clickhouseops_simplerole.reader.name, clickhouseops_simplerole.writer.name]
+  grantees    = [clickhouseops_simpleuser.alice.name]
+  set_default = true
+}
@@ -14,4 +14,15 @@ resource "clickhouseops_materializedview" "new_view" {
   sql                  = <<EOT
 SELECT 1
 EOT
-}
\ No newline at end of file
+}
+resource "clickhouseops_materializedview" "auto_view" {
+  name                 = "auto_view"
+  database_name        = clickhouse_database.source.name
+  target_database_name = clickhouse_database.target.name
+  target_table_name    = "auto_test"
+  auto_create_target   = true
+  target_order_by      = ["id"]
+  sql                  = <<EOT
+SELECT toUInt64(1) AS id, 'name' AS name
+EOT
+}
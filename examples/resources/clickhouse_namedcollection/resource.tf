@@ -3,7 +3,8 @@ resource "clickhouseops_database" "test" {
 }
 
 resource "clickhouseops_namedcollection" "test" {
-  name = "test"
+  name   = "test"
+  preset = "postgresql"
   keyvaluepairs = [{
     key   = "host"
     value = "localhost"
@@ -13,9 +14,13 @@ resource "clickhouseops_namedcollection" "test" {
     }, {
     key   = "user"
     value = "user"
+    }, {
+    key                = "database"
+    value              = "test"
+    is_not_overridable = true
   }]
   sensitive_keyvaluepairs = [{
-    key   = "password"
-    value = "password"
+    key    = "password"
+    secret = "postgres_password"
   }]
 }
\ No newline at end of file
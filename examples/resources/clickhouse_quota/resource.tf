@@ -0,0 +1,20 @@
+resource "clickhouseops_simplerole" "analyst" {
+  name = "analyst"
+}
+
+resource "clickhouseops_quota" "analysts" {
+  name     = "analysts"
+  keyed_by = "user_name"
+  intervals = [{
+    duration = 1
+    unit     = "hour"
+    queries  = 1000
+    errors   = 100
+    }, {
+    duration       = 1
+    unit           = "day"
+    read_rows      = 1000000000
+    execution_time = 3600
+  }]
+  to = [clickhouseops_simplerole.analyst.name]
+}
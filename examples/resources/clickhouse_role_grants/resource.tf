@@ -0,0 +1,29 @@
+resource "clickhouseops_simplerole" "reporting" {
+  name = "reporting"
+}
+
+resource "clickhouseops_simplerole" "analyst" {
+  name = "analyst"
+}
+
+# Any privilege or role granted to the role outside of this resource is revoked
+resource "clickhouseops_role_grants" "reporting" {
+  assignee = clickhouseops_simplerole.reporting.name
+  grants = [{
+    privilege     = "SELECT"
+    database_name = "analytics"
+    }, {
+    privilege     = "SELECT"
+    database_name = "analytics"
+    table_name    = "salaries"
+    revoked       = true
+    }, {
+    privilege     = "SELECT"
+    database_name = "sales"
+    table_name    = "orders"
+    columns       = ["id", "amount"]
+    }, {
+    privilege = "SHOW DATABASES"
+  }]
+  roles = [clickhouseops_simplerole.analyst.name]
+}
@@ -0,0 +1,29 @@
+resource "clickhouseops_database" "tenants" {
+  name = "tenants"
+}
+
+resource "clickhouseops_mergetree" "events" {
+  name          = "events"
+  database_name = clickhouseops_database.tenants.name
+  columns = [{
+    name = "tenant_id"
+    type = "UInt64"
+    }, {
+    name = "payload"
+    type = "String"
+  }]
+  order_by = ["tenant_id"]
+}
+
+resource "clickhouseops_simplerole" "tenant_42" {
+  name = "tenant_42"
+}
+
+resource "clickhouseops_row_policy" "tenant_42" {
+  name          = "tenant_42"
+  database_name = clickhouseops_database.tenants.name
+  table_name    = clickhouseops_mergetree.events.name
+  as            = "RESTRICTIVE"
+  using         = "tenant_id = 42"
+  to            = [clickhouseops_simplerole.tenant_42.name]
+}
@@ -0,0 +1,18 @@
+resource "clickhouseops_simplerole" "analyst" {
+  name = "analyst"
+}
+
+resource "clickhouseops_settings_profile" "analysts" {
+  name = "analysts"
+  settings = [{
+    name  = "max_memory_usage"
+    value = "10000000000"
+    max   = "20000000000"
+    }, {
+    name        = "readonly"
+    value       = "1"
+    writability = "CONST"
+  }]
+  inherit = ["default"]
+  to      = [clickhouseops_simplerole.analyst.name]
+}
@@ -0,0 +1,9 @@
+resource "clickhouseops_simplerole" "etl" {
+  name    = "etl"
+  profile = "default"
+  settings = [{
+    name  = "max_memory_usage"
+    value = "50000000000"
+    max   = "100000000000"
+  }]
+}
@@ -0,0 +1,55 @@
+resource "clickhouseops_simpleuser" "analyst" {
+  name            = "analyst"
+  sha256_password = sha256("dummy_password")
+}
+
+# Service account authenticated with its mTLS certificate
+resource "clickhouseops_simpleuser" "ingestion" {
+  name = "ingestion"
+  authentication = {
+    method       = "ssl_certificate"
+    common_names = ["ingestion.mesh.local"]
+  }
+}
+
+# Human user authenticated against an LDAP server defined in the server configuration
+resource "clickhouseops_simpleuser" "alice" {
+  name = "alice"
+  authentication = {
+    method = "ldap"
+    server = "corporate_ldap"
+  }
+}
+
+# Password, validity, hosts and settings changes are applied in place with ALTER USER
+resource "clickhouseops_simpleuser" "reporting" {
+  name                  = "reporting"
+  sha256_password       = sha256("dummy_password")
+  valid_datetime        = "2030-01-01 00:00:00"
+  default_database_name = "analytics"
+  hosts = [{
+    type  = "IP"
+    value = "10.0.0.0/8"
+    }, {
+    type = "LOCAL"
+  }]
+  grantees = ["NONE"]
+  profile  = "readonly"
+  settings = [{
+    name  = "max_memory_usage"
+    value = "10000000000"
+  }]
+}
+
+# Password generated by the provider, only its SHA256 hash is sent to Clickhouse
+resource "clickhouseops_simpleuser" "etl" {
+  name = "etl"
+  generate_password = {
+    length = 40
+  }
+}
+
+output "etl_password" {
+  value     = clickhouseops_simpleuser.etl.password
+  sensitive = true
+}
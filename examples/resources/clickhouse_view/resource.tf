@@ -18,3 +18,14 @@ ORDER BY event_time DESC
 LIMIT 10
 EOT
 }
+
+resource "clickhouseops_view" "curated_view" {
+  name          = "curated_view"
+  database_name = clickhouse_database.test.name
+  definer       = "CURRENT_USER"
+  sql_security  = "DEFINER"
+  comment       = "Curated view for BI users"
+  sql           = <<EOT
+SELECT number FROM system.numbers WHERE number = {id:UInt64} LIMIT 1
+EOT
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
@@ -19,6 +20,26 @@ var functions = template.FuncMap{
 		}
 		return -1
 	},
+	"escape":  EscapeString,
+	"definer": Definer,
+}
+
+// EscapeString escapes value to be rendered between single quotes.
+func EscapeString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+// QuoteIdentifier renders name as a back quoted identifier.
+func QuoteIdentifier(name string) string {
+	return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name) + "`"
+}
+
+// Definer renders the user of a DEFINER clause, CURRENT_USER is kept as a keyword.
+func Definer(name string) string {
+	if strings.EqualFold(name, "CURRENT_USER") {
+		return "CURRENT_USER"
+	}
+	return QuoteIdentifier(name)
 }
 
 func RenderTemplate(queryTemplate string, input any) (*string, error) {
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterDataSource{}
+
+func NewClusterDataSource() datasource.DataSource {
+	return &ClusterDataSource{}
+}
+
+// ClusterDataSource describes the topology of a cluster found in system.clusters.
+type ClusterDataSource struct {
+	db clickhouse.Conn
+}
+
+type ClusterDataSourceModel struct {
+	Id           types.String            `tfsdk:"id"`
+	Name         types.String            `tfsdk:"name"`
+	ShardCount   types.Int64             `tfsdk:"shard_count"`
+	ReplicaCount types.Int64             `tfsdk:"replica_count"`
+	Shards       []ClusterShardDataModel `tfsdk:"shards"`
+	Macros       map[string]types.String `tfsdk:"macros"`
+}
+
+type ClusterShardDataModel struct {
+	ShardNum types.Int64               `tfsdk:"shard_num"`
+	Weight   types.Int64               `tfsdk:"weight"`
+	Replicas []ClusterReplicaDataModel `tfsdk:"replicas"`
+}
+
+type ClusterReplicaDataModel struct {
+	ReplicaNum  types.Int64  `tfsdk:"replica_num"`
+	HostName    types.String `tfsdk:"host_name"`
+	HostAddress types.String `tfsdk:"host_address"`
+	Port        types.Int64  `tfsdk:"port"`
+	IsLocal     types.Bool   `tfsdk:"is_local"`
+}
+
+func (d *ClusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to retrieve the shards and replicas of a cluster from system.clusters, and the macros of the connected server from system.macros",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Cluster name, reading fails when the cluster is not defined",
+				Required:            true,
+			},
+			"shard_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of shards",
+				Computed:            true,
+			},
+			"replica_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of replicas of the first shard",
+				Computed:            true,
+			},
+			"shards": schema.ListNestedAttribute{
+				MarkdownDescription: "Shards ordered by shard_num",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"shard_num": schema.Int64Attribute{
+							MarkdownDescription: "Shard number, starting from 1",
+							Computed:            true,
+						},
+						"weight": schema.Int64Attribute{
+							MarkdownDescription: "Relative weight of the shard when writing data",
+							Computed:            true,
+						},
+						"replicas": schema.ListNestedAttribute{
+							MarkdownDescription: "Replicas ordered by replica_num",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"replica_num": schema.Int64Attribute{
+										MarkdownDescription: "Replica number in the shard, starting from 1",
+										Computed:            true,
+									},
+									"host_name": schema.StringAttribute{
+										MarkdownDescription: "Host name as specified in the config",
+										Computed:            true,
+									},
+									"host_address": schema.StringAttribute{
+										MarkdownDescription: "Host IP address obtained from DNS",
+										Computed:            true,
+									},
+									"port": schema.Int64Attribute{
+										MarkdownDescription: "Port to use for connecting to the server",
+										Computed:            true,
+									},
+									"is_local": schema.BoolAttribute{
+										MarkdownDescription: "Whether the host is the server the provider is connected to",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"macros": schema.MapAttribute{
+				MarkdownDescription: "Macros of the server the provider is connected to, ie. shard and replica",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ClusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readClusterQuery = `
+SELECT toInt64(shard_num), toInt64(shard_weight), toInt64(replica_num), host_name, host_address, toInt64(port), toBool(is_local)
+FROM system.clusters
+WHERE cluster = ?
+ORDER BY shard_num, replica_num
+`
+
+const readMacrosQuery = `SELECT macro, substitution FROM system.macros`
+
+func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := d.db.Query(ctx, readClusterQuery, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Cluster",
+			"Could not read system.clusters, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	data.Shards = nil
+	for rows.Next() {
+		var shardNum, weight, replicaNum, port int64
+		var hostName, hostAddress string
+		var isLocal bool
+		if err := rows.Scan(&shardNum, &weight, &replicaNum, &hostName, &hostAddress, &port, &isLocal); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Cluster",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if len(data.Shards) == 0 || data.Shards[len(data.Shards)-1].ShardNum.ValueInt64() != shardNum {
+			data.Shards = append(data.Shards, ClusterShardDataModel{
+				ShardNum: types.Int64Value(shardNum),
+				Weight:   types.Int64Value(weight),
+			})
+		}
+		shard := &data.Shards[len(data.Shards)-1]
+		shard.Replicas = append(shard.Replicas, ClusterReplicaDataModel{
+			ReplicaNum:  types.Int64Value(replicaNum),
+			HostName:    types.StringValue(hostName),
+			HostAddress: types.StringValue(hostAddress),
+			Port:        types.Int64Value(port),
+			IsLocal:     types.BoolValue(isLocal),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Cluster",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+	rows.Close()
+
+	if len(data.Shards) == 0 {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Cluster",
+			"Could not find cluster "+data.Name.ValueString()+" in system.clusters",
+		)
+		return
+	}
+	data.ShardCount = types.Int64Value(int64(len(data.Shards)))
+	data.ReplicaCount = types.Int64Value(int64(len(data.Shards[0].Replicas)))
+
+	macros, err := d.db.Query(ctx, readMacrosQuery)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Cluster",
+			"Could not read system.macros, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer macros.Close()
+
+	data.Macros = map[string]types.String{}
+	for macros.Next() {
+		var macro, substitution string
+		if err := macros.Scan(&macro, &substitution); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Cluster",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		data.Macros[macro] = types.StringValue(substitution)
+	}
+	if err := macros.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Cluster",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Id = data.Name
+
+	tflog.Trace(ctx, "read cluster data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccClusterDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_cluster.default", "shard_count", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_cluster.default", "replica_count", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_cluster.default", "shards.0.shard_num", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_cluster.default", "shards.0.replicas.0.port", "9000"),
+					resource.TestCheckResourceAttr("data.clickhouseops_cluster.default", "shards.0.replicas.0.is_local", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccClusterDataSourceConfig = `
+data "clickhouseops_cluster" "default" {
+  name = "default"
+}
+`
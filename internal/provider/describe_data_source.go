@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &DescribeDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &DescribeDataSource{}
+)
+
+func NewDescribeDataSource() datasource.DataSource {
+	return &DescribeDataSource{}
+}
+
+// DescribeDataSource retrieves the columns of any Clickhouse table function.
+type DescribeDataSource struct {
+	db clickhouse.Conn
+}
+
+type DescribeDataSourceModel struct {
+	Id                  types.String            `tfsdk:"id"`
+	Function            types.String            `tfsdk:"function"`
+	NamedCollectionName types.String            `tfsdk:"named_collection_name"`
+	Arguments           []types.String          `tfsdk:"arguments"`
+	Parameters          map[string]types.String `tfsdk:"parameters"`
+	ClickhouseColumns   []ClickhouseColumn      `tfsdk:"clickhouseops_columns"`
+}
+
+var tableFunctionName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func (d *DescribeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_describe"
+}
+
+func (d *DescribeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to retrieve schema using any Clickhouse table function and describe clause, ie. url, file, mysql, postgresql, hdfs or azureBlobStorage",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"function": schema.StringAttribute{
+				MarkdownDescription: "Table function name, ie. url, file, mysql, postgresql, hdfs or azureBlobStorage",
+				Required:            true,
+			},
+			"named_collection_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse Named Collection containing the configuration for the table function",
+				Optional:            true,
+			},
+			"arguments": schema.ListAttribute{
+				MarkdownDescription: "Positional arguments of the table function, rendered as quoted strings. Can not be used with named_collection_name",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"parameters": schema.MapAttribute{
+				MarkdownDescription: "Key value arguments overriding the named collection, ie. `{ table = \"users\" }`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"clickhouseops_columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns returned by the table function converted to Clickhouse columns",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Column name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DescribeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+func (d *DescribeDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data DescribeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Function.IsUnknown() && !tableFunctionName.MatchString(data.Function.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("function"),
+			"Invalid Attribute Configuration",
+			"Expect function to be a table function name, got: "+data.Function.ValueString(),
+		)
+	}
+	if data.NamedCollectionName.IsNull() {
+		if len(data.Parameters) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("parameters"),
+				"Invalid Attribute Configuration",
+				"Expect named_collection_name to be set when parameters are used",
+			)
+		}
+	} else if len(data.Arguments) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("arguments"),
+			"Invalid Attribute Configuration",
+			"Expect arguments not to be set with named_collection_name, use parameters instead",
+		)
+	}
+}
+
+/*
+DESCRIBE table_function(arg1 [, arg2, ...])
+DESCRIBE table_function(named_collection [, key=value, ...])
+*/
+
+const describeTemplate = `
+DESCRIBE {{.Function.ValueString}}(
+{{- if not .NamedCollectionName.IsNull}}{{.NamedCollectionName.ValueString}}{{range $k, $v := .Parameters}}, {{$k}}='{{$v.ValueString}}'{{end}}
+{{- else}}{{$size := size .Arguments}}{{range $i, $e := .Arguments}}'{{$e.ValueString}}'{{if lt $i $size}}, {{end}}{{end}}{{end -}}
+)
+`
+
+func (d *DescribeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DescribeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(describeTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error trying to get Clickhouse columns from table function",
+			"Could not render DESCRIBE, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	described, err := describeColumns(ctx, d.db, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error trying to get Clickhouse columns from table function",
+			"Could not execute DESCRIBE: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var columns []ClickhouseColumn
+	for _, col := range described {
+		columns = append(columns, ClickhouseColumn{
+			Name: types.StringValue(col.Name),
+			Type: types.StringValue(col.Type),
+		})
+	}
+
+	data.Id = types.StringValue(data.Function.ValueString() + ":" + data.NamedCollectionName.ValueString())
+	data.ClickhouseColumns = columns
+
+	tflog.Trace(ctx, "read describe data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDescribeDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDescribeDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_describe.random", "clickhouseops_columns.#", "2"),
+					resource.TestCheckResourceAttr("data.clickhouseops_describe.random", "clickhouseops_columns.0.name", "a"),
+					resource.TestCheckResourceAttr("data.clickhouseops_describe.random", "clickhouseops_columns.0.type", "UInt8"),
+					resource.TestCheckResourceAttr("data.clickhouseops_describe.random", "clickhouseops_columns.1.name", "b"),
+					resource.TestCheckResourceAttr("data.clickhouseops_describe.random", "clickhouseops_columns.1.type", "String"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDescribeDataSourceConfig = `
+data "clickhouseops_describe" "random" {
+  function = "generateRandom"
+  arguments = ["a UInt8, b String"]
+}
+`
@@ -0,0 +1,515 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+var (
+	_ resource.Resource                   = &Dictionary{}
+	_ resource.ResourceWithConfigure      = &Dictionary{}
+	_ resource.ResourceWithImportState    = &Dictionary{}
+	_ resource.ResourceWithValidateConfig = &Dictionary{}
+)
+
+func NewDictionary() resource.Resource {
+	return &Dictionary{}
+}
+
+type Dictionary struct {
+	db clickhouse.Conn
+}
+
+type DictionaryModel struct {
+	ID               types.String               `tfsdk:"id"`
+	Name             types.String               `tfsdk:"name"`
+	DatabaseName     types.String               `tfsdk:"database_name"`
+	ClusterName      types.String               `tfsdk:"cluster_name"`
+	Attributes       []DictionaryAttributeModel `tfsdk:"attributes"`
+	PrimaryKey       []types.String             `tfsdk:"primary_key"`
+	Source           *DictionarySourceModel     `tfsdk:"source"`
+	Layout           types.String               `tfsdk:"layout"`
+	LayoutParameters []DictionaryParameterModel `tfsdk:"layout_parameters"`
+	LifetimeMin      types.Int64                `tfsdk:"lifetime_min"`
+	LifetimeMax      types.Int64                `tfsdk:"lifetime_max"`
+	RangeMin         types.String               `tfsdk:"range_min"`
+	RangeMax         types.String               `tfsdk:"range_max"`
+	Comment          types.String               `tfsdk:"comment"`
+	Status           types.String               `tfsdk:"status"`
+}
+
+type DictionaryAttributeModel struct {
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Default      types.String `tfsdk:"default"`
+	Expression   types.String `tfsdk:"expression"`
+	Hierarchical types.Bool   `tfsdk:"hierarchical"`
+	Injective    types.Bool   `tfsdk:"injective"`
+}
+
+type DictionarySourceModel struct {
+	Type                types.String               `tfsdk:"type"`
+	NamedCollectionName types.String               `tfsdk:"named_collection_name"`
+	Parameters          []DictionaryParameterModel `tfsdk:"parameters"`
+}
+
+type DictionaryParameterModel struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (r *Dictionary) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dictionary"
+}
+
+func (r *Dictionary) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse Dictionary",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse Dictionary Name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse Database Name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse Cluster Name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "Clickhouse Dictionary Attribute List, including the primary key columns",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse Dictionary Attribute name",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse Dictionary Attribute type",
+							Required:            true,
+						},
+						"default": schema.StringAttribute{
+							MarkdownDescription: "Default value expression used when the key is not found, ie. `''` or `0`",
+							Optional:            true,
+						},
+						"expression": schema.StringAttribute{
+							MarkdownDescription: "Expression computed by the source for the attribute value",
+							Optional:            true,
+						},
+						"hierarchical": schema.BoolAttribute{
+							MarkdownDescription: "If true the attribute contains the parent key for hierarchical dictionaries",
+							Optional:            true,
+						},
+						"injective": schema.BoolAttribute{
+							MarkdownDescription: "If true the attribute is an injective mapping of the key",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"primary_key": schema.ListAttribute{
+				MarkdownDescription: "Clickhouse Dictionary primary key columns, more than one column requires a COMPLEX_KEY layout",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"source": schema.SingleNestedAttribute{
+				MarkdownDescription: "Clickhouse Dictionary source",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Source type, ie. CLICKHOUSE, POSTGRESQL, MYSQL, HTTP, FILE, etc.",
+						Required:            true,
+					},
+					"named_collection_name": schema.StringAttribute{
+						MarkdownDescription: "Clickhouse Named Collection containing the source configuration",
+						Optional:            true,
+					},
+					"parameters": schema.ListNestedAttribute{
+						MarkdownDescription: "Source parameters, ie. host, port, db, table, query, url, etc.",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "Source parameter name",
+									Required:            true,
+								},
+								"value": schema.StringAttribute{
+									MarkdownDescription: "Source parameter value",
+									Required:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"layout": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse Dictionary layout, ie. FLAT, HASHED, COMPLEX_KEY_HASHED, RANGE_HASHED, IP_TRIE, CACHE, etc.",
+				Required:            true,
+			},
+			"layout_parameters": schema.ListNestedAttribute{
+				MarkdownDescription: "Layout parameters, ie. SIZE_IN_CELLS for CACHE",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Layout parameter name",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Layout parameter value",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"lifetime_min": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of seconds between dictionary updates",
+				Optional:            true,
+			},
+			"lifetime_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds between dictionary updates, 0 disables updates",
+				Optional:            true,
+			},
+			"range_min": schema.StringAttribute{
+				MarkdownDescription: "Attribute with the start of the range for RANGE_HASHED layouts",
+				Optional:            true,
+			},
+			"range_max": schema.StringAttribute{
+				MarkdownDescription: "Attribute with the end of the range for RANGE_HASHED layouts",
+				Optional:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse Dictionary comment",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Dictionary status as reported by system.dictionaries, ie. NOT_LOADED, LOADED, FAILED, etc.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *Dictionary) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *Dictionary) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DictionaryModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.LifetimeMin.IsNull() && data.LifetimeMax.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("lifetime_min"),
+			"Missing Attribute Configuration",
+			"Expect lifetime_max to be defined when lifetime_min is set",
+		)
+	}
+
+	if data.Layout.IsUnknown() || data.Layout.IsNull() {
+		return
+	}
+	layout := strings.ToUpper(data.Layout.ValueString())
+
+	if !strings.HasPrefix(layout, "COMPLEX_KEY_") && layout != "IP_TRIE" && len(data.PrimaryKey) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("primary_key"),
+			"Invalid Attribute Configuration",
+			"Expect a single primary key column for layout "+layout+", use a COMPLEX_KEY layout for composite keys",
+		)
+	}
+
+	isRange := strings.HasSuffix(layout, "RANGE_HASHED")
+	hasRange := !data.RangeMin.IsNull() || !data.RangeMax.IsNull()
+	if isRange && (data.RangeMin.IsNull() || data.RangeMax.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("layout"),
+			"Missing Attribute Configuration",
+			"Expect range_min and range_max to be defined for layout "+layout,
+		)
+	}
+	if !isRange && hasRange {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("layout"),
+			"Invalid Attribute Configuration",
+			"range_min and range_max are only supported by RANGE_HASHED layouts",
+		)
+	}
+}
+
+/*
+	Clickhouse Dictionary Syntax for reference
+
+CREATE [OR REPLACE] DICTIONARY [IF NOT EXISTS] [db.]dictionary_name [ON CLUSTER cluster]
+(
+
+	key1 type1  [DEFAULT|EXPRESSION expr1] [IS_OBJECT_ID],
+	key2 type2  [DEFAULT|EXPRESSION expr2],
+	attr1 type2 [DEFAULT|EXPRESSION expr3] [HIERARCHICAL|INJECTIVE],
+	attr2 type2 [DEFAULT|EXPRESSION expr4] [HIERARCHICAL|INJECTIVE]
+
+)
+PRIMARY KEY key1, key2
+SOURCE(SOURCE_NAME([param1 value1 ... paramN valueN]))
+LAYOUT(LAYOUT_NAME([param_name param_value]))
+LIFETIME({MIN min_val MAX max_val | max_val})
+SETTINGS(setting_name = setting_value, setting_name = setting_value, ...)
+COMMENT 'Comment'
+*/
+const ddlCreateDictionaryTemplate = `
+CREATE OR REPLACE DICTIONARY "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+(
+{{$size := size .Attributes}}
+{{range $i, $e := .Attributes}}
+"{{$e.Name.ValueString}}" {{$e.Type.ValueString}}{{if not $e.Default.IsNull}} DEFAULT {{$e.Default.ValueString}}{{end}}{{if not $e.Expression.IsNull}} EXPRESSION {{$e.Expression.ValueString}}{{end}}{{if $e.Hierarchical.ValueBool}} HIERARCHICAL{{end}}{{if $e.Injective.ValueBool}} INJECTIVE{{end}}{{if lt $i $size}},{{end}}
+{{end}}
+)
+{{$size := size .PrimaryKey}}PRIMARY KEY {{range $i, $e := .PrimaryKey}}"{{$e.ValueString}}"{{if lt $i $size}},{{end}}{{end}}
+SOURCE({{.Source.Type.ValueString}}({{if not .Source.NamedCollectionName.IsNull}}NAME "{{.Source.NamedCollectionName.ValueString}}" {{end}}{{range .Source.Parameters}}{{.Name.ValueString}} '{{.Value.ValueString}}' {{end}}))
+LAYOUT({{.Layout.ValueString}}({{range .LayoutParameters}}{{.Name.ValueString}} {{.Value.ValueString}} {{end}}))
+{{if not .LifetimeMax.IsNull}}LIFETIME({{if not .LifetimeMin.IsNull}}MIN {{.LifetimeMin.ValueInt64}} MAX {{end}}{{.LifetimeMax.ValueInt64}}){{end}}
+{{if not .RangeMin.IsNull}}RANGE(MIN "{{.RangeMin.ValueString}}" MAX "{{.RangeMax.ValueString}}"){{end}}
+{{if not .Comment.IsNull}}COMMENT '{{.Comment.ValueString}}'{{end}}
+`
+
+/*
+DROP DICTIONARY [IF EXISTS] [db.]name [ON CLUSTER cluster] [SYNC]
+.
+*/
+const ddlDropDictionaryTemplate = `
+DROP DICTIONARY IF EXISTS "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+`
+
+const readDictionaryStatusQuery = `SELECT toString(status) FROM system.dictionaries WHERE database = ? AND name = ?`
+
+func (r *Dictionary) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DictionaryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateDictionaryTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Dictionary",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Dictionary",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.DatabaseName.ValueString() + ":" + data.Name.ValueString())
+
+	status, err := r.readStatus(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Dictionary",
+			"Could not read system.dictionaries, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.Status = types.StringPointerValue(status)
+
+	tflog.Trace(ctx, "Created a Dictionary Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Dictionary) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DictionaryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := r.readStatus(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Dictionary",
+			"Could not read system.dictionaries, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if status == nil {
+		tflog.Trace(ctx, "Dictionary not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Status = types.StringPointerValue(status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Dictionary) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *DictionaryModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateDictionaryTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Dictionary",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Dictionary",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	status, err := r.readStatus(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Dictionary",
+			"Could not read system.dictionaries, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.Status = types.StringPointerValue(status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Dictionary) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DictionaryModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlDropDictionaryTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts an ID in the form [cluster_name:]database_name:name. Only the status is read back,
+// the definition is taken from the configuration on the next apply.
+func (r *Dictionary) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) == 2 {
+		parts = append([]string{""}, parts...)
+	}
+	if len(parts) != 3 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier with format: [cluster_name:]database_name:name, got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(parts, ":"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+	if parts[0] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), parts[0])...)
+	}
+}
+
+// readStatus returns the dictionary status, or nil when the dictionary does not exist.
+func (r *Dictionary) readStatus(ctx context.Context, data *DictionaryModel) (*string, error) {
+	rows, err := r.db.Query(ctx, readDictionaryStatusQuery, data.DatabaseName.ValueString(), data.Name.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var status string
+	if err := rows.Scan(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDictionaryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDictionaryResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_dictionary.new_dictionary", "name", "countries"),
+					resource.TestCheckResourceAttrSet("clickhouseops_dictionary.new_dictionary", "status"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "clickhouseops_dictionary.new_dictionary",
+				ImportState:       true,
+				ImportStateId:     "dictionaries:countries",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"attributes", "primary_key", "source", "layout", "layout_parameters",
+					"lifetime_min", "lifetime_max", "range_min", "range_max", "comment",
+				},
+			},
+		},
+	})
+}
+
+const testAccDictionaryResourceConfig = `
+resource "clickhouseops_database" "dictionaries" {
+	name = "dictionaries"
+}
+
+resource "clickhouseops_mergetree" "countries_source" {
+	name = "countries_source"
+	database_name = clickhouseops_database.dictionaries.name
+	columns = [{
+		name = "id"
+		type = "UInt64"
+	},{
+		name = "name"
+		type = "String"
+	}]
+	order_by = ["id"]
+}
+
+resource "clickhouseops_dictionary" "new_dictionary" {
+	name = "countries"
+	database_name = clickhouseops_database.dictionaries.name
+	attributes = [{
+		name = "id"
+		type = "UInt64"
+	},{
+		name = "name"
+		type = "String"
+		default = "''"
+	}]
+	primary_key = ["id"]
+	source = {
+		type = "CLICKHOUSE"
+		parameters = [{
+			name = "db"
+			value = clickhouseops_database.dictionaries.name
+		},{
+			name = "table"
+			value = clickhouseops_mergetree.countries_source.name
+		}]
+	}
+	layout = "HASHED"
+	lifetime_min = 0
+	lifetime_max = 300
+}
+`
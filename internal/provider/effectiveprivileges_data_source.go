@@ -0,0 +1,400 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EffectivePrivilegesDataSource{}
+
+func NewEffectivePrivilegesDataSource() datasource.DataSource {
+	return &EffectivePrivilegesDataSource{}
+}
+
+// EffectivePrivilegesDataSource resolves every privilege a user holds directly or through nested roles.
+type EffectivePrivilegesDataSource struct {
+	db clickhouse.Conn
+}
+
+type EffectivePrivilegesDataSourceModel struct {
+	Id           types.String                  `tfsdk:"id"`
+	UserName     types.String                  `tfsdk:"user_name"`
+	Privilege    types.String                  `tfsdk:"privilege"`
+	DatabaseName types.String                  `tfsdk:"database_name"`
+	TableName    types.String                  `tfsdk:"table_name"`
+	Column       types.String                  `tfsdk:"column"`
+	Allowed      types.Bool                    `tfsdk:"allowed"`
+	Roles        []types.String                `tfsdk:"roles"`
+	Privileges   []EffectivePrivilegeDataModel `tfsdk:"privileges"`
+}
+
+type EffectivePrivilegeDataModel struct {
+	Source          types.String `tfsdk:"source"`
+	Privilege       types.String `tfsdk:"privilege"`
+	DatabaseName    types.String `tfsdk:"database_name"`
+	TableName       types.String `tfsdk:"table_name"`
+	Column          types.String `tfsdk:"column"`
+	IsPartialRevoke types.Bool   `tfsdk:"is_partial_revoke"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+}
+
+func (d *EffectivePrivilegesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_privileges"
+}
+
+func (d *EffectivePrivilegesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to resolve the privileges of a user, including the ones inherited through nested roles. " +
+			"Every granted role is taken into account, not only the default ones, since the user can enable them with SET ROLE",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "User to resolve the privileges of",
+				Required:            true,
+			},
+			"privilege": schema.StringAttribute{
+				MarkdownDescription: "Privilege to check, ie. SELECT or INSERT, `allowed` is only computed when set",
+				Optional:            true,
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Database the checked privilege applies to, every database when omitted",
+				Optional:            true,
+			},
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Table the checked privilege applies to, every table when omitted",
+				Optional:            true,
+			},
+			"column": schema.StringAttribute{
+				MarkdownDescription: "Column the checked privilege applies to, every column when omitted",
+				Optional:            true,
+			},
+			"allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user holds privilege on database_name.table_name(column), null when privilege is not set",
+				Computed:            true,
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "Roles granted to the user directly or through other roles",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"privileges": schema.ListNestedAttribute{
+				MarkdownDescription: "Privileges and partial revokes of the user and of every role in roles",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							MarkdownDescription: "User or role the privilege is granted to",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege, ie. SELECT or INSERT",
+							Computed:            true,
+						},
+						"database_name": schema.StringAttribute{
+							MarkdownDescription: "Database the privilege applies to, null for every database",
+							Computed:            true,
+						},
+						"table_name": schema.StringAttribute{
+							MarkdownDescription: "Table the privilege applies to, null for every table",
+							Computed:            true,
+						},
+						"column": schema.StringAttribute{
+							MarkdownDescription: "Column the privilege applies to, null for every column",
+							Computed:            true,
+						},
+						"is_partial_revoke": schema.BoolAttribute{
+							MarkdownDescription: "Whether this entry revokes part of a wider privilege of the same source",
+							Computed:            true,
+						},
+						"with_grant_option": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege is granted WITH GRANT OPTION",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EffectivePrivilegesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readEffectiveUserQuery = `SELECT name FROM system.users WHERE name = ?`
+
+const readEffectiveRolesQuery = `
+SELECT ifNull(user_name, role_name), granted_role_name
+FROM system.role_grants
+`
+
+const readEffectivePrivilegesQuery = `
+SELECT ifNull(user_name, role_name) AS source, toString(access_type), database, table, column, toBool(is_partial_revoke), toBool(grant_option)
+FROM system.grants
+WHERE has(?, source)
+ORDER BY source, database, table, access_type, column
+`
+
+const readPrivilegesHierarchyQuery = `SELECT toString(privilege), toString(parent_group), aliases FROM system.privileges`
+
+func (d *EffectivePrivilegesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EffectivePrivilegesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := d.db.Query(ctx, readEffectiveUserQuery, data.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Effective Privileges",
+			"Could not read system.users, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	found := rows.Next()
+	rows.Close()
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Effective Privileges",
+			"Could not find user "+data.UserName.ValueString(),
+		)
+		return
+	}
+
+	roles, err := d.readRoles(ctx, data.UserName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Effective Privileges",
+			"Could not read system.role_grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	privileges, err := d.readPrivileges(ctx, append([]string{data.UserName.ValueString()}, roles...))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Effective Privileges",
+			"Could not read system.grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Allowed = types.BoolNull()
+	if !data.Privilege.IsNull() {
+		hierarchy, err := d.readHierarchy(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Effective Privileges",
+				"Could not read system.privileges, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		data.Allowed = types.BoolValue(hierarchy.allowed(privileges, &data))
+	}
+
+	data.Roles = stringValues(roles)
+	data.Privileges = privileges
+	data.Id = types.StringValue(data.UserName.ValueString())
+
+	tflog.Trace(ctx, "read effective privileges data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readRoles walks system.role_grants from the user and returns every reachable role, sorted by name.
+func (d *EffectivePrivilegesDataSource) readRoles(ctx context.Context, userName string) ([]string, error) {
+	rows, err := d.db.Query(ctx, readEffectiveRolesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	granted := map[string][]string{}
+	for rows.Next() {
+		var assignee, role string
+		if err := rows.Scan(&assignee, &role); err != nil {
+			return nil, err
+		}
+		granted[assignee] = append(granted[assignee], role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	pending := granted[userName]
+	for len(pending) > 0 {
+		role := pending[0]
+		pending = pending[1:]
+		if seen[role] {
+			continue
+		}
+		seen[role] = true
+		pending = append(pending, granted[role]...)
+	}
+
+	var roles []string
+	for role := range seen {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+func (d *EffectivePrivilegesDataSource) readPrivileges(ctx context.Context, sources []string) ([]EffectivePrivilegeDataModel, error) {
+	rows, err := d.db.Query(ctx, readEffectivePrivilegesQuery, sources)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []EffectivePrivilegeDataModel
+	for rows.Next() {
+		var source, privilege string
+		var database, table, column *string
+		var isPartialRevoke, grantOption bool
+		if err := rows.Scan(&source, &privilege, &database, &table, &column, &isPartialRevoke, &grantOption); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, EffectivePrivilegeDataModel{
+			Source:          types.StringValue(source),
+			Privilege:       types.StringValue(privilege),
+			DatabaseName:    types.StringPointerValue(database),
+			TableName:       types.StringPointerValue(table),
+			Column:          types.StringPointerValue(column),
+			IsPartialRevoke: types.BoolValue(isPartialRevoke),
+			WithGrantOption: types.BoolValue(grantOption),
+		})
+	}
+	return privileges, rows.Err()
+}
+
+// privilegeHierarchy maps every privilege and alias of system.privileges to its canonical name and parent group.
+type privilegeHierarchy struct {
+	names   map[string]string
+	parents map[string]string
+}
+
+func (d *EffectivePrivilegesDataSource) readHierarchy(ctx context.Context) (*privilegeHierarchy, error) {
+	rows, err := d.db.Query(ctx, readPrivilegesHierarchyQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hierarchy := &privilegeHierarchy{names: map[string]string{}, parents: map[string]string{}}
+	for rows.Next() {
+		var privilege string
+		var parent *string
+		var aliases []string
+		if err := rows.Scan(&privilege, &parent, &aliases); err != nil {
+			return nil, err
+		}
+		hierarchy.names[strings.ToUpper(privilege)] = privilege
+		for _, alias := range aliases {
+			hierarchy.names[strings.ToUpper(alias)] = privilege
+		}
+		if parent != nil {
+			hierarchy.parents[privilege] = *parent
+		}
+	}
+	return hierarchy, rows.Err()
+}
+
+// ancestors returns the privilege itself followed by every group containing it, up to ALL.
+func (h *privilegeHierarchy) ancestors(privilege string) []string {
+	name, ok := h.names[strings.ToUpper(privilege)]
+	if !ok {
+		name = strings.ToUpper(privilege)
+	}
+	result := []string{name}
+	for parent, ok := h.parents[name]; ok && len(result) <= len(h.parents); parent, ok = h.parents[parent] {
+		result = append(result, parent)
+	}
+	return append(result, "ALL")
+}
+
+// covers reports whether privilege is the same as or a group containing other.
+func (h *privilegeHierarchy) covers(privilege string, other string) bool {
+	name := h.ancestors(privilege)[0]
+	for _, ancestor := range h.ancestors(other) {
+		if ancestor == name {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed reports whether any source grants the checked privilege without one of its own partial revokes
+// overlapping it. ClickHouse applies partial revokes per user or role before merging their rights.
+func (h *privilegeHierarchy) allowed(privileges []EffectivePrivilegeDataModel, check *EffectivePrivilegesDataSourceModel) bool {
+	matches := func(scope types.String, value types.String) bool {
+		return scope.IsNull() || (!value.IsNull() && scope.ValueString() == value.ValueString())
+	}
+	overlaps := func(scope types.String, value types.String) bool {
+		return scope.IsNull() || value.IsNull() || scope.ValueString() == value.ValueString()
+	}
+
+	granted := map[string]bool{}
+	revoked := map[string]bool{}
+	for _, privilege := range privileges {
+		source := privilege.Source.ValueString()
+		if privilege.IsPartialRevoke.ValueBool() {
+			if (h.covers(privilege.Privilege.ValueString(), check.Privilege.ValueString()) ||
+				h.covers(check.Privilege.ValueString(), privilege.Privilege.ValueString())) &&
+				overlaps(privilege.DatabaseName, check.DatabaseName) &&
+				overlaps(privilege.TableName, check.TableName) &&
+				overlaps(privilege.Column, check.Column) {
+				revoked[source] = true
+			}
+			continue
+		}
+		if h.covers(privilege.Privilege.ValueString(), check.Privilege.ValueString()) &&
+			matches(privilege.DatabaseName, check.DatabaseName) &&
+			matches(privilege.TableName, check.TableName) &&
+			matches(privilege.Column, check.Column) {
+			granted[source] = true
+		}
+	}
+
+	for source := range granted {
+		if !revoked[source] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEffectivePrivilegesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEffectivePrivilegesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_effective_privileges.can_select", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.clickhouseops_effective_privileges.can_select", "roles.0", "effective_parent"),
+					resource.TestCheckResourceAttr("data.clickhouseops_effective_privileges.can_select", "roles.1", "effective_reader"),
+					resource.TestCheckResourceAttr("data.clickhouseops_effective_privileges.can_select", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.clickhouseops_effective_privileges.can_insert", "allowed", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEffectivePrivilegesDataSourceConfig = `
+resource "clickhouseops_simplerole" "reader" {
+  name = "effective_reader"
+}
+
+resource "clickhouseops_simplerole" "parent" {
+  name = "effective_parent"
+}
+
+resource "clickhouseops_simpleuser" "user" {
+  name = "effective_user"
+  sha256_password = sha256("dummy_password")
+}
+
+resource "clickhouseops_grantselect" "reader" {
+  database_name = "system"
+  table_name = "tables"
+  assignee = clickhouseops_simplerole.reader.name
+}
+
+resource "clickhouseops_grantrole" "nested" {
+  role_name = clickhouseops_simplerole.reader.name
+  grantees = [clickhouseops_simplerole.parent.name]
+}
+
+resource "clickhouseops_grantrole" "user" {
+  role_name = clickhouseops_simplerole.parent.name
+  user_name = clickhouseops_simpleuser.user.name
+}
+
+data "clickhouseops_effective_privileges" "can_select" {
+  user_name = clickhouseops_simpleuser.user.name
+  privilege = "SELECT"
+  database_name = "system"
+  table_name = "tables"
+  depends_on = [clickhouseops_grantselect.reader, clickhouseops_grantrole.nested, clickhouseops_grantrole.user]
+}
+
+data "clickhouseops_effective_privileges" "can_insert" {
+  user_name = clickhouseops_simpleuser.user.name
+  privilege = "INSERT"
+  database_name = "system"
+  table_name = "tables"
+  depends_on = [clickhouseops_grantselect.reader, clickhouseops_grantrole.nested, clickhouseops_grantrole.user]
+}
+`
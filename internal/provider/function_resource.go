@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+var (
+	_ resource.Resource                = &Function{}
+	_ resource.ResourceWithConfigure   = &Function{}
+	_ resource.ResourceWithImportState = &Function{}
+)
+
+func NewFunction() resource.Resource {
+	return &Function{}
+}
+
+type Function struct {
+	db clickhouse.Conn
+}
+
+type FunctionModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	ClusterName types.String   `tfsdk:"cluster_name"`
+	Parameters  []types.String `tfsdk:"parameters"`
+	Expression  types.String   `tfsdk:"expression"`
+}
+
+func (r *Function) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_function"
+}
+
+func (r *Function) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse SQL user defined function",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse function name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse cluster name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.ListAttribute{
+				MarkdownDescription: "Function parameter names",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"expression": schema.StringAttribute{
+				MarkdownDescription: "Lambda expression using the function parameters",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *Function) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+/*
+	Clickhouse Function Syntax for reference
+
+CREATE [OR REPLACE] FUNCTION name [ON CLUSTER cluster] AS (parameter0, ...) -> expression
+*/
+const ddlCreateFunctionTemplate = `
+CREATE OR REPLACE FUNCTION "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} AS
+{{$size := size .Parameters}}({{range $i, $e := .Parameters}}{{$e.ValueString}}{{if lt $i $size}},{{end}}{{end}}) -> {{.Expression.ValueString}}
+`
+
+/*
+DROP FUNCTION [IF EXISTS] function_name [on CLUSTER cluster]
+.
+*/
+const ddlDropFunctionTemplate = `
+DROP FUNCTION IF EXISTS "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+`
+
+const readFunctionQuery = `SELECT create_query FROM system.functions WHERE name = ? AND origin = 'SQLUserDefined'`
+
+// functionCreateQueryRegexp extracts parameters and expression from system.functions create_query.
+var functionCreateQueryRegexp = regexp.MustCompile(`(?s)\sAS\s+\(([^)]*)\)\s*->\s*(.*)$`)
+
+func (r *Function) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *FunctionModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateFunctionTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Function",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Function",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Name.ValueString())
+
+	tflog.Trace(ctx, "Created a Function Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Function) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *FunctionModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := r.db.Query(ctx, readFunctionQuery, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Function",
+			"Could not read system.functions, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		tflog.Trace(ctx, "Function not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var createQuery string
+	if err := rows.Scan(&createQuery); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Function",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Only populated after an import, otherwise the configured expression is kept
+	// as the server normalizes it.
+	if data.Expression.IsNull() {
+		if match := functionCreateQueryRegexp.FindStringSubmatch(createQuery); match != nil {
+			data.Parameters = nil
+			for _, parameter := range strings.Split(match[1], ",") {
+				if parameter = strings.TrimSpace(parameter); parameter != "" {
+					data.Parameters = append(data.Parameters, types.StringValue(parameter))
+				}
+			}
+			data.Expression = types.StringValue(strings.TrimSpace(match[2]))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Function) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *FunctionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateFunctionTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Function",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Function",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Function) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *FunctionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlDropFunctionTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts either the function name or the resource ID in the form cluster_name:name.
+func (r *Function) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByName(ctx, req, resp)
+}
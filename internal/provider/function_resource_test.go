@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFunctionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFunctionResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_function.linear_equation", "name", "linear_equation"),
+					resource.TestCheckResourceAttr("clickhouseops_function.linear_equation", "parameters.#", "3"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "clickhouseops_function.linear_equation",
+				ImportState:             true,
+				ImportStateId:           "linear_equation",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"expression"},
+			},
+			// Update in place
+			{
+				Config: testAccFunctionResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_function.linear_equation", "expression", "k*x + b + 1"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccFunctionResourceConfig = `
+resource "clickhouseops_function" "linear_equation" {
+	name = "linear_equation"
+	parameters = ["x", "k", "b"]
+	expression = "k*x + b"
+}
+`
+	testAccFunctionResourceUpdatedConfig = `
+resource "clickhouseops_function" "linear_equation" {
+	name = "linear_equation"
+	parameters = ["x", "k", "b"]
+	expression = "k*x + b + 1"
+}
+`
+)
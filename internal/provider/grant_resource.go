@@ -0,0 +1,547 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+var (
+	_ resource.Resource                   = &Grant{}
+	_ resource.ResourceWithConfigure      = &Grant{}
+	_ resource.ResourceWithImportState    = &Grant{}
+	_ resource.ResourceWithValidateConfig = &Grant{}
+)
+
+func NewGrant() resource.Resource {
+	return &Grant{}
+}
+
+type Grant struct {
+	db clickhouse.Conn
+}
+
+type GrantModel struct {
+	ID              types.String          `tfsdk:"id"`
+	ClusterName     types.String          `tfsdk:"cluster_name"`
+	Privileges      []GrantPrivilegeModel `tfsdk:"privileges"`
+	DatabaseName    types.String          `tfsdk:"database_name"`
+	TableName       types.String          `tfsdk:"table_name"`
+	NamedCollection types.String          `tfsdk:"named_collection"`
+	WithGrantOption types.Bool            `tfsdk:"with_grant_option"`
+	Assignee        types.String          `tfsdk:"assignee"`
+}
+
+type GrantPrivilegeModel struct {
+	Privilege types.String   `tfsdk:"privilege"`
+	Columns   []types.String `tfsdk:"columns"`
+}
+
+// Target returns the GRANT target, *.* when neither a database nor a named collection is set.
+func (m GrantModel) Target() string {
+	switch {
+	case !m.NamedCollection.IsNull():
+		return `"` + m.NamedCollection.ValueString() + `"`
+	case m.DatabaseName.IsNull():
+		return "*.*"
+	case m.TableName.IsNull():
+		return `"` + m.DatabaseName.ValueString() + `".*`
+	default:
+		return `"` + m.DatabaseName.ValueString() + `"."` + m.TableName.ValueString() + `"`
+	}
+}
+
+func (r *Grant) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant"
+}
+
+func (r *Grant) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse grant of privileges on a target to a user or a role (Assignee)",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse cluster name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileges": schema.SetNestedAttribute{
+				MarkdownDescription: "Privileges to grant",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege name as shown in system.privileges, e.g. INSERT, CREATE TABLE, dictGet, SYSTEM RELOAD or S3",
+							Required:            true,
+						},
+						"columns": schema.ListAttribute{
+							MarkdownDescription: "Columns the privilege is restricted to, all columns when omitted",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the database the privileges are granted on, all databases (`*.*`) when omitted",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table the privileges are granted on, all tables of the database when omitted",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"named_collection": schema.StringAttribute{
+				MarkdownDescription: "Name of the named collection the privileges are granted on, conflicts with database_name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				MarkdownDescription: "If true the assignee can grant the privileges to others",
+				Optional:            true,
+			},
+			"assignee": schema.StringAttribute{
+				MarkdownDescription: "User or Role you want grant permissions",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *Grant) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *Grant) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GrantModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.NamedCollection.IsNull() && !data.DatabaseName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("named_collection"),
+			"Invalid Attribute Configuration",
+			"Expect only one of named_collection or database_name to be set",
+		)
+	}
+	if !data.TableName.IsNull() && data.DatabaseName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_name"),
+			"Invalid Attribute Configuration",
+			"Expect database_name to be set when table_name is set",
+		)
+	}
+	for _, privilege := range data.Privileges {
+		if len(privilege.Columns) > 0 && data.TableName.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid Attribute Configuration",
+				"Expect table_name to be set when privilege "+privilege.Privilege.ValueString()+" is restricted to columns",
+			)
+		}
+	}
+}
+
+/*
+	Clickhouse Grant Syntax for reference
+
+GRANT [ON CLUSTER cluster_name] privilege[(column_name [,...])] [,...] ON {db.table|db.*|*.*|table|*} TO {user | role | CURRENT_USER} [,...] [WITH GRANT OPTION] [WITH REPLACE OPTION].
+*/
+const ddlGrantTemplate = `
+GRANT {{if not .ClusterName.IsNull}}ON CLUSTER '{{.ClusterName.ValueString}}' {{end}}{{template "privileges" .}} ON {{.Target}} TO '{{.Assignee.ValueString}}'{{if .GrantOption}} WITH GRANT OPTION{{end}}
+`
+
+/*
+REVOKE [ON CLUSTER cluster_name] [GRANT OPTION FOR] privilege[(column_name [,...])] [,...] ON {db.table|db.*|*.*|table|*} FROM {user | CURRENT_USER} [,...] | ALL | ALL EXCEPT {user | CURRENT_USER} [,...]
+.
+*/
+const ddlRevokeTemplate = `
+REVOKE {{if not .ClusterName.IsNull}}ON CLUSTER '{{.ClusterName.ValueString}}' {{end}}{{if .GrantOption}}GRANT OPTION FOR {{end}}{{template "privileges" .}} ON {{.Target}} FROM '{{.Assignee.ValueString}}'
+`
+
+const privilegesTemplate = `
+{{define "privileges"}}{{$size := size .Privileges}}{{range $i, $e := .Privileges}}{{$e.Privilege.ValueString}}{{$size_columns := size $e.Columns}}{{with $e.Columns}}({{range $j, $c := .}}"{{$c.ValueString}}"{{if lt $j $size_columns}},{{end}}{{end}}){{end}}{{if lt $i $size}}, {{end}}{{end}}{{end}}
+`
+
+const readGrantsQuery = `
+SELECT toString(access_type), column, grant_option
+FROM system.grants
+WHERE (user_name = ? OR role_name = ?) AND is_partial_revoke = 0 AND ifNull(database, '') = ? AND ifNull(table, '') = ?
+`
+
+type GrantStatement struct {
+	*GrantModel
+	Privileges  []GrantPrivilegeModel
+	GrantOption bool
+}
+
+// privilegePair is a privilege on a single column, Column is empty for the whole target.
+type privilegePair struct {
+	Privilege string
+	Column    string
+}
+
+// key returns the pair with the privilege name upper cased, privileges are case insensitive.
+func (p privilegePair) key() privilegePair {
+	return privilegePair{Privilege: strings.ToUpper(p.Privilege), Column: p.Column}
+}
+
+func privilegePairs(privileges []GrantPrivilegeModel) []privilegePair {
+	var pairs []privilegePair
+	for _, privilege := range privileges {
+		name := privilege.Privilege.ValueString()
+		if len(privilege.Columns) == 0 {
+			pairs = append(pairs, privilegePair{Privilege: name})
+		}
+		for _, column := range privilege.Columns {
+			pairs = append(pairs, privilegePair{Privilege: name, Column: column.ValueString()})
+		}
+	}
+	return pairs
+}
+
+// subtractPrivileges returns the privileges of from which are not part of other, merging columns back by privilege.
+func subtractPrivileges(from, other []GrantPrivilegeModel) []GrantPrivilegeModel {
+	exclude := map[privilegePair]bool{}
+	for _, pair := range privilegePairs(other) {
+		exclude[pair.key()] = true
+	}
+
+	var result []GrantPrivilegeModel
+	index := map[string]int{}
+	for _, pair := range privilegePairs(from) {
+		if exclude[pair.key()] {
+			continue
+		}
+		i, ok := index[strings.ToUpper(pair.Privilege)]
+		if !ok {
+			i = len(result)
+			index[strings.ToUpper(pair.Privilege)] = i
+			result = append(result, GrantPrivilegeModel{Privilege: types.StringValue(pair.Privilege)})
+		}
+		if pair.Column != "" {
+			result[i].Columns = append(result[i].Columns, types.StringValue(pair.Column))
+		}
+	}
+	return result
+}
+
+// execGrant renders and executes a GRANT or REVOKE statement, nothing is executed without privileges.
+func execGrant(ctx context.Context, db clickhouse.Conn, template string, statement GrantStatement) error {
+	if len(statement.Privileges) == 0 {
+		return nil
+	}
+
+	query, err := common.RenderTemplate(template+privilegesTemplate, statement)
+	if err != nil {
+		return fmt.Errorf("could not render DDL, unexpected error: %w", err)
+	}
+
+	err = db.Exec(ctx, *query)
+	if err != nil {
+		return fmt.Errorf("could not execute DDL: %s, unexpected error: %w", *query, err)
+	}
+	return nil
+}
+
+func (r *Grant) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *GrantModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := execGrant(ctx, r.db, ddlGrantTemplate, GrantStatement{GrantModel: data, Privileges: data.Privileges, GrantOption: data.WithGrantOption.ValueBool()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Granting Permissions",
+			err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Assignee.ValueString() + ":" + grantTargetID(data))
+
+	tflog.Trace(ctx, "Created a Grant Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// grantTargetID returns the target as used in the resource ID: db.table, db.*, *.* or the named collection.
+func grantTargetID(data *GrantModel) string {
+	switch {
+	case !data.NamedCollection.IsNull():
+		return data.NamedCollection.ValueString()
+	case data.DatabaseName.IsNull():
+		return "*.*"
+	case data.TableName.IsNull():
+		return data.DatabaseName.ValueString() + ".*"
+	default:
+		return data.DatabaseName.ValueString() + "." + data.TableName.ValueString()
+	}
+}
+
+func (r *Grant) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *GrantModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Named collection grants are reported with the collection name in the database column.
+	database := data.DatabaseName.ValueString()
+	if !data.NamedCollection.IsNull() {
+		database = data.NamedCollection.ValueString()
+	}
+
+	rows, err := r.db.Query(ctx, readGrantsQuery, data.Assignee.ValueString(), data.Assignee.ValueString(), database, data.TableName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Grants",
+			"Could not read system.grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	var granted []GrantPrivilegeModel
+	grantOption := true
+	for rows.Next() {
+		var accessType string
+		var column *string
+		var option uint8
+		if err := rows.Scan(&accessType, &column, &option); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Grants",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		privilege := GrantPrivilegeModel{Privilege: types.StringValue(accessType)}
+		if column != nil {
+			privilege.Columns = []types.String{types.StringValue(*column)}
+		}
+		granted = append(granted, privilege)
+		grantOption = grantOption && option != 0
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Grants",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Merge the per column rows, only privileges managed by this resource are kept unless imported.
+	granted = subtractPrivileges(granted, nil)
+	if data.Privileges != nil {
+		granted = managedPrivileges(data.Privileges, granted)
+	}
+	if len(granted) == 0 {
+		tflog.Trace(ctx, "Grant not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Privileges = granted
+
+	if grantOption != data.WithGrantOption.ValueBool() {
+		data.WithGrantOption = types.BoolValue(grantOption)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// managedPrivileges returns the granted privileges which are configured, keeping the configured
+// privilege name and column order.
+func managedPrivileges(configured, granted []GrantPrivilegeModel) []GrantPrivilegeModel {
+	byName := map[string]GrantPrivilegeModel{}
+	for _, privilege := range granted {
+		byName[strings.ToUpper(privilege.Privilege.ValueString())] = privilege
+	}
+
+	var result []GrantPrivilegeModel
+	for _, privilege := range configured {
+		current, ok := byName[strings.ToUpper(privilege.Privilege.ValueString())]
+		if !ok {
+			continue
+		}
+		result = append(result, GrantPrivilegeModel{
+			Privilege: privilege.Privilege,
+			Columns:   orderColumns(privilege.Columns, current.Columns),
+		})
+	}
+	return result
+}
+
+// orderColumns returns the granted columns, the configured ones first in their configured order.
+func orderColumns(configured, granted []types.String) []types.String {
+	remaining := map[string]bool{}
+	for _, column := range granted {
+		remaining[column.ValueString()] = true
+	}
+
+	var result []types.String
+	for _, column := range configured {
+		if remaining[column.ValueString()] {
+			result = append(result, column)
+			delete(remaining, column.ValueString())
+		}
+	}
+	for _, column := range granted {
+		if remaining[column.ValueString()] {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+func (r *Grant) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *GrantModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant := subtractPrivileges(data.Privileges, state.Privileges)
+	if data.WithGrantOption.ValueBool() && !state.WithGrantOption.ValueBool() {
+		grant = data.Privileges
+	}
+
+	statements := []struct {
+		template  string
+		statement GrantStatement
+	}{
+		{ddlRevokeTemplate, GrantStatement{GrantModel: data, Privileges: subtractPrivileges(state.Privileges, data.Privileges)}},
+		{ddlGrantTemplate, GrantStatement{GrantModel: data, Privileges: grant, GrantOption: data.WithGrantOption.ValueBool()}},
+	}
+	if !data.WithGrantOption.ValueBool() && state.WithGrantOption.ValueBool() {
+		statements = append(statements, struct {
+			template  string
+			statement GrantStatement
+		}{ddlRevokeTemplate, GrantStatement{GrantModel: data, Privileges: data.Privileges, GrantOption: true}})
+	}
+
+	for _, s := range statements {
+		if err := execGrant(ctx, r.db, s.template, s.statement); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Grant) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *GrantModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := execGrant(ctx, r.db, ddlRevokeTemplate, GrantStatement{GrantModel: data, Privileges: data.Privileges})
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts an ID in the form [cluster_name:]assignee:target where target is
+// db.table, db.*, *.* or a named collection name.
+func (r *Grant) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) == 2 {
+		parts = append([]string{""}, parts...)
+	}
+	if len(parts) != 3 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier with format: [cluster_name:]assignee:target, got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(parts, ":"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("assignee"), parts[1])...)
+	if parts[0] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), parts[0])...)
+	}
+
+	database, table, found := strings.Cut(parts[2], ".")
+	switch {
+	case !found:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("named_collection"), parts[2])...)
+	case database == "*":
+	case table == "*":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), database)...)
+	default:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), database)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"), table)...)
+	}
+}
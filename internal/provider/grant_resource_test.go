@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGrantResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGrantResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grant.ingestion", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("clickhouseops_grant.ingestion", "id", ":ingestion:ingestion.events"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "clickhouseops_grant.ingestion",
+				ImportState:       true,
+				ImportStateId:     "ingestion:ingestion.events",
+				ImportStateVerify: true,
+			},
+			// Incremental grant and revoke
+			{
+				Config: testAccGrantResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grant.ingestion", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_grant.ingestion", "with_grant_option", "true"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccGrantResourceConfig = `
+resource "clickhouseops_database" "ingestion" {
+	name = "ingestion"
+}
+
+resource "clickhouseops_mergetree" "events" {
+	name = "events"
+	database_name = clickhouseops_database.ingestion.name
+	columns = [{
+		name = "id"
+		type = "UInt64"
+	},{
+		name = "payload"
+		type = "String"
+	}]
+	order_by = ["id"]
+}
+
+resource "clickhouseops_simplerole" "ingestion" {
+	name = "ingestion"
+}
+
+resource "clickhouseops_grant" "ingestion" {
+	database_name = clickhouseops_database.ingestion.name
+	table_name = clickhouseops_mergetree.events.name
+	privileges = [{
+		privilege = "INSERT"
+	}]
+	assignee = clickhouseops_simplerole.ingestion.name
+}
+`
+	testAccGrantResourceUpdatedConfig = `
+resource "clickhouseops_database" "ingestion" {
+	name = "ingestion"
+}
+
+resource "clickhouseops_mergetree" "events" {
+	name = "events"
+	database_name = clickhouseops_database.ingestion.name
+	columns = [{
+		name = "id"
+		type = "UInt64"
+	},{
+		name = "payload"
+		type = "String"
+	}]
+	order_by = ["id"]
+}
+
+resource "clickhouseops_simplerole" "ingestion" {
+	name = "ingestion"
+}
+
+resource "clickhouseops_grant" "ingestion" {
+	database_name = clickhouseops_database.ingestion.name
+	table_name = clickhouseops_mergetree.events.name
+	privileges = [{
+		privilege = "INSERT"
+	},{
+		privilege = "SELECT"
+		columns = ["id"]
+	}]
+	with_grant_option = true
+	assignee = clickhouseops_simplerole.ingestion.name
+}
+`
+)
@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -19,9 +21,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &GrantRole{}
-	_ resource.ResourceWithConfigure   = &GrantRole{}
-	_ resource.ResourceWithImportState = &GrantRole{}
+	_ resource.Resource                   = &GrantRole{}
+	_ resource.ResourceWithConfigure      = &GrantRole{}
+	_ resource.ResourceWithImportState    = &GrantRole{}
+	_ resource.ResourceWithModifyPlan     = &GrantRole{}
+	_ resource.ResourceWithValidateConfig = &GrantRole{}
 )
 
 func NewGrantRole() resource.Resource {
@@ -33,10 +37,48 @@ type GrantRole struct {
 }
 
 type GrantRoleModel struct {
-	ID          types.String `tfsdk:"id"`
-	RoleName    types.String `tfsdk:"role_name"`
-	UserName    types.String `tfsdk:"user_name"`
-	ClusterName types.String `tfsdk:"cluster_name"`
+	ID              types.String   `tfsdk:"id"`
+	RoleName        types.String   `tfsdk:"role_name"`
+	RoleNames       []types.String `tfsdk:"role_names"`
+	UserName        types.String   `tfsdk:"user_name"`
+	Grantees        []types.String `tfsdk:"grantees"`
+	WithAdminOption types.Bool     `tfsdk:"with_admin_option"`
+	SetDefault      types.Bool     `tfsdk:"set_default"`
+	ClusterName     types.String   `tfsdk:"cluster_name"`
+}
+
+// AllRoles returns the granted roles, role_name and role_names combined.
+func (m GrantRoleModel) AllRoles() []types.String {
+	if !m.RoleName.IsNull() {
+		return append([]types.String{m.RoleName}, m.RoleNames...)
+	}
+	return m.RoleNames
+}
+
+// AllGrantees returns the users and roles the roles are granted to, user_name and grantees combined.
+func (m GrantRoleModel) AllGrantees() []types.String {
+	if !m.UserName.IsNull() {
+		return append([]types.String{m.UserName}, m.Grantees...)
+	}
+	return m.Grantees
+}
+
+// grantRoleID returns the resource ID, cluster_name:roles:grantees with sorted roles and grantees,
+// or unknown while a role or grantee is not known.
+func grantRoleID(data *GrantRoleModel) types.String {
+	var parts []string
+	for _, values := range [][]types.String{data.AllRoles(), data.AllGrantees()} {
+		var names []string
+		for _, value := range values {
+			if value.IsUnknown() {
+				return types.StringUnknown()
+			}
+			names = append(names, value.ValueString())
+		}
+		sort.Strings(names)
+		parts = append(parts, strings.Join(names, ","))
+	}
+	return types.StringValue(data.ClusterName.ValueString() + ":" + parts[0] + ":" + parts[1])
 }
 
 func (r *GrantRole) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,28 +87,37 @@ func (r *GrantRole) Metadata(ctx context.Context, req resource.MetadataRequest,
 
 func (r *GrantRole) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Clickhouse assign role to a user",
+		MarkdownDescription: "Clickhouse assign roles to users or roles",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
 			},
 			"role_name": schema.StringAttribute{
 				MarkdownDescription: "Role name to assign to the user",
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Optional:            true,
+			},
+			"role_names": schema.SetAttribute{
+				MarkdownDescription: "Role names to assign to the grantees",
+				ElementType:         types.StringType,
+				Optional:            true,
 			},
 			"user_name": schema.StringAttribute{
 				MarkdownDescription: "Clickhouse username",
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Optional:            true,
+			},
+			"grantees": schema.SetAttribute{
+				MarkdownDescription: "Users or roles the roles are assigned to",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"with_admin_option": schema.BoolAttribute{
+				MarkdownDescription: "If true the grantees can grant the roles to others",
+				Optional:            true,
+			},
+			"set_default": schema.BoolAttribute{
+				MarkdownDescription: "If true the roles are added to the default roles of the grantees, which must be users. Turning it off removes the roles from their default roles",
+				Optional:            true,
 			},
 			"cluster_name": schema.StringAttribute{
 				MarkdownDescription: "Clickhouse cluster name",
@@ -98,12 +149,138 @@ func (r *GrantRole) Configure(ctx context.Context, req resource.ConfigureRequest
 	r.db = db
 }
 
+// ModifyPlan plans the ID from the roles and grantees, which are updated in place.
+func (r *GrantRole) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data GrantRoleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), grantRoleID(&data))...)
+}
+
+func (r *GrantRole) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GrantRoleModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.RoleName.IsNull() && data.RoleNames == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_names"),
+			"Invalid Attribute Configuration",
+			"Expect at least one of role_name or role_names to be set",
+		)
+	}
+	if data.UserName.IsNull() && data.Grantees == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("grantees"),
+			"Invalid Attribute Configuration",
+			"Expect at least one of user_name or grantees to be set",
+		)
+	}
+}
+
+type GrantRoleStatement struct {
+	*GrantRoleModel
+	Roles       []types.String
+	Grantees    []types.String
+	AdminOption bool
+}
+
 /*
 	Clickhouse Grant Syntax for reference
 
 GRANT [ON CLUSTER cluster_name] role [,...] TO {user | another_role | CURRENT_USER} [,...] [WITH ADMIN OPTION] [WITH REPLACE OPTION].
 */
-const ddlCreateGrantRoleTemplate = `GRANT {{if not .ClusterName.IsNull}}ON CLUSTER '{{.ClusterName.ValueString}}' {{end}}'{{.RoleName.ValueString}}' TO '{{.UserName.ValueString}}'`
+const ddlCreateGrantRoleTemplate = `GRANT {{if not .ClusterName.IsNull}}ON CLUSTER '{{.ClusterName.ValueString}}' {{end}}{{$size := size .Roles}}{{range $i, $e := .Roles}}'{{$e.ValueString}}'{{if lt $i $size}},{{end}}{{end}} TO {{$size_grantees := size .Grantees}}{{range $i, $e := .Grantees}}'{{$e.ValueString}}'{{if lt $i $size_grantees}},{{end}}{{end}}{{if .AdminOption}} WITH ADMIN OPTION{{end}}`
+
+/*
+ALTER USER [IF EXISTS] name [ON CLUSTER cluster_name] [DEFAULT ROLE role [,...] | ALL | ALL EXCEPT role [,...] ]
+.
+*/
+const ddlDefaultRoleGrantRoleTemplate = `ALTER USER {{range .Grantees}}'{{.ValueString}}'{{end}}{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} DEFAULT ROLE {{with .Roles}}{{$size := size .}}{{range $i, $e := .}}'{{$e.ValueString}}'{{if lt $i $size}},{{end}}{{end}}{{else}}NONE{{end}}`
+
+const readGrantRoleQuery = `
+SELECT ifNull(user_name, role_name), granted_role_name, granted_role_is_default, with_admin_option
+FROM system.role_grants
+WHERE has(?, ifNull(user_name, role_name)) AND has(?, granted_role_name)
+`
+
+const readDefaultRolesQuery = `SELECT granted_role_name FROM system.role_grants WHERE user_name = ? AND granted_role_is_default = 1`
+
+// execGrantRole renders and executes a role GRANT or REVOKE statement, nothing is executed without roles or grantees.
+func execGrantRole(ctx context.Context, db clickhouse.Conn, template string, statement GrantRoleStatement) error {
+	if len(statement.Roles) == 0 || len(statement.Grantees) == 0 {
+		return nil
+	}
+
+	query, err := common.RenderTemplate(template, statement)
+	if err != nil {
+		return fmt.Errorf("could not render DDL, unexpected error: %w", err)
+	}
+
+	err = db.Exec(ctx, *query)
+	if err != nil {
+		return fmt.Errorf("could not execute DDL, unexpected error: %s%w", *query, err)
+	}
+	return nil
+}
+
+// setDefaultRoles adds the granted roles to the default roles of each grantee, or removes them when set is false,
+// keeping their other default roles.
+func (r *GrantRole) setDefaultRoles(ctx context.Context, data *GrantRoleModel, set bool) error {
+	for _, grantee := range data.AllGrantees() {
+		rows, err := r.db.Query(ctx, readDefaultRolesQuery, grantee.ValueString())
+		if err != nil {
+			return err
+		}
+
+		var roles []types.String
+		if set {
+			roles = data.AllRoles()
+		}
+		granted := map[string]bool{}
+		for _, role := range data.AllRoles() {
+			granted[role.ValueString()] = true
+		}
+		changed := set
+		for rows.Next() {
+			var role string
+			if err := rows.Scan(&role); err != nil {
+				rows.Close()
+				return err
+			}
+			if granted[role] {
+				changed = true
+				continue
+			}
+			roles = append(roles, types.StringValue(role))
+		}
+		rows.Close()
+		if !changed {
+			continue
+		}
+
+		// Rendered directly as no roles left resets the default roles to NONE.
+		statement := GrantRoleStatement{GrantRoleModel: data, Roles: roles, Grantees: []types.String{grantee}}
+		query, err := common.RenderTemplate(ddlDefaultRoleGrantRoleTemplate, statement)
+		if err != nil {
+			return fmt.Errorf("could not render DDL, unexpected error: %w", err)
+		}
+		if err := r.db.Exec(ctx, *query); err != nil {
+			return fmt.Errorf("could not execute DDL, unexpected error: %s%w", *query, err)
+		}
+	}
+	return nil
+}
 
 func (r *GrantRole) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *GrantRoleModel
@@ -113,24 +290,24 @@ func (r *GrantRole) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlCreateGrantRoleTemplate, data)
-	if err != nil {
+	statement := GrantRoleStatement{GrantRoleModel: data, Roles: data.AllRoles(), Grantees: data.AllGrantees(), AdminOption: data.WithAdminOption.ValueBool()}
+	if err := execGrantRole(ctx, r.db, ddlCreateGrantRoleTemplate, statement); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Granting Permissions",
-			"Could not render DDL, unexpected error: "+err.Error(),
+			err.Error(),
 		)
 		return
 	}
-
-	err = r.db.Exec(ctx, *query)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Granting Permissions",
-			"Could not execute DDL, unexpected error: "+*query+err.Error(),
-		)
-		return
+	if data.SetDefault.ValueBool() {
+		if err := r.setDefaultRoles(ctx, data, true); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Granting Permissions",
+				"Could not set default roles, unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
-	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.RoleName.ValueString() + ":" + data.UserName.ValueString())
+	data.ID = grantRoleID(data)
 
 	tflog.Trace(ctx, "Created a GrantRole Resource")
 
@@ -148,6 +325,80 @@ func (r *GrantRole) Read(ctx context.Context, req resource.ReadRequest, resp *re
 		return
 	}
 
+	var grantees, roles []string
+	for _, grantee := range data.AllGrantees() {
+		grantees = append(grantees, grantee.ValueString())
+	}
+	for _, role := range data.AllRoles() {
+		roles = append(roles, role.ValueString())
+	}
+
+	rows, err := r.db.Query(ctx, readGrantRoleQuery, grantees, roles)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Role Grants",
+			"Could not read system.role_grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	// A grantee is kept when it is granted any of the roles, and a role only when it is granted to every kept
+	// grantee, the next apply grants them again otherwise.
+	granted := map[string]int{}
+	assigned := map[string]bool{}
+	adminOption, isDefault := true, true
+	for rows.Next() {
+		var grantee, role string
+		var roleIsDefault, withAdminOption uint8
+		if err := rows.Scan(&grantee, &role, &roleIsDefault, &withAdminOption); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Role Grants",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		granted[role]++
+		assigned[grantee] = true
+		adminOption = adminOption && withAdminOption != 0
+		isDefault = isDefault && roleIsDefault != 0
+	}
+	if len(granted) == 0 {
+		tflog.Trace(ctx, "Role grants not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !assigned[data.UserName.ValueString()] {
+		data.UserName = types.StringNull()
+	}
+	var granteeNames []types.String
+	for _, grantee := range data.Grantees {
+		if assigned[grantee.ValueString()] {
+			granteeNames = append(granteeNames, grantee)
+		}
+	}
+	data.Grantees = granteeNames
+
+	if granted[data.RoleName.ValueString()] < len(assigned) {
+		data.RoleName = types.StringNull()
+	}
+	var roleNames []types.String
+	for _, role := range data.RoleNames {
+		if granted[role.ValueString()] >= len(assigned) {
+			roleNames = append(roleNames, role)
+		}
+	}
+	data.RoleNames = roleNames
+	data.ID = grantRoleID(data)
+
+	if adminOption != data.WithAdminOption.ValueBool() {
+		data.WithAdminOption = types.BoolValue(adminOption)
+	}
+	if data.SetDefault.ValueBool() && !isDefault {
+		data.SetDefault = types.BoolValue(isDefault)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -155,19 +406,59 @@ func (r *GrantRole) Read(ctx context.Context, req resource.ReadRequest, resp *re
 }
 
 func (r *GrantRole) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *GrantRoleModel
+	var data, state *GrantRoleModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	statements := []struct {
+		template  string
+		statement GrantRoleStatement
+	}{
+		// Roles no longer granted, then grantees no longer assigned
+		{ddlDestroyGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: data, Roles: subtractColumns(state.AllRoles(), data.AllRoles()), Grantees: state.AllGrantees()}},
+		{ddlDestroyGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: data, Roles: state.AllRoles(), Grantees: subtractColumns(state.AllGrantees(), data.AllGrantees())}},
+		{ddlCreateGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: data, Roles: data.AllRoles(), Grantees: data.AllGrantees(), AdminOption: data.WithAdminOption.ValueBool()}},
+	}
+	if !data.WithAdminOption.ValueBool() && state.WithAdminOption.ValueBool() {
+		statements = append(statements, struct {
+			template  string
+			statement GrantRoleStatement
+		}{ddlDestroyGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: data, Roles: data.AllRoles(), Grantees: data.AllGrantees(), AdminOption: true}})
+	}
+
+	for _, s := range statements {
+		if err := execGrantRole(ctx, r.db, s.template, s.statement); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				err.Error(),
+			)
+			return
+		}
+	}
+	if data.SetDefault.ValueBool() || state.SetDefault.ValueBool() {
+		if err := r.setDefaultRoles(ctx, data, data.SetDefault.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				"Could not set default roles, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-const ddlDestroyGrantRoleTemplate = `REVOKE {{if not .ClusterName.IsNull}}ON CLUSTER '{{.ClusterName.ValueString}}' {{end}}'{{.RoleName.ValueString}}' FROM '{{.UserName.ValueString}}'`
+/*
+REVOKE [ON CLUSTER cluster_name] [ADMIN OPTION FOR] role [,...] FROM {user | role | CURRENT_USER} [,...] | ALL | ALL EXCEPT {user_name | role_name | CURRENT_USER} [,...]
+.
+*/
+const ddlDestroyGrantRoleTemplate = `REVOKE {{if not .ClusterName.IsNull}}ON CLUSTER '{{.ClusterName.ValueString}}' {{end}}{{if .AdminOption}}ADMIN OPTION FOR {{end}}{{$size := size .Roles}}{{range $i, $e := .Roles}}'{{$e.ValueString}}'{{if lt $i $size}},{{end}}{{end}} FROM {{$size_grantees := size .Grantees}}{{range $i, $e := .Grantees}}'{{$e.ValueString}}'{{if lt $i $size_grantees}},{{end}}{{end}}`
 
 func (r *GrantRole) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data *GrantRoleModel
@@ -179,19 +470,36 @@ func (r *GrantRole) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlDestroyGrantRoleTemplate, data)
+	err := execGrantRole(ctx, r.db, ddlDestroyGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: data, Roles: data.AllRoles(), Grantees: data.AllGrantees()})
 	if err != nil {
 		resp.Diagnostics.AddError("", ""+err.Error())
 		return
 	}
+}
 
-	err = r.db.Exec(ctx, *query)
-	if err != nil {
-		resp.Diagnostics.AddError("", ""+err.Error())
+// ImportState accepts an ID in the form cluster_name:roles:grantees, roles and grantees being comma separated.
+func (r *GrantRole) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 3 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier with format: cluster_name:roles:grantees, got: "+req.ID,
+		)
 		return
 	}
-}
 
-func (r *GrantRole) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	if parts[0] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), parts[0])...)
+	}
+	if roles := strings.Split(parts[1], ","); len(roles) == 1 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roles[0])...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_names"), roles)...)
+	}
+	if grantees := strings.Split(parts[2], ","); len(grantees) == 1 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_name"), grantees[0])...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grantees"), grantees)...)
+	}
 }
@@ -40,3 +40,90 @@ resource "clickhouseops_grantrole" "user1_role1" {
 	role_name = clickhouseops_simplerole.role1.name
 }
 `
+
+func TestAccGrantRolesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGrantRolesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grantrole.onboarding", "role_names.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_grantrole.onboarding", "set_default", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "clickhouseops_grantrole.onboarding",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"set_default"},
+			},
+			// Update in place
+			{
+				Config: testAccGrantRolesUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grantrole.onboarding", "role_names.#", "1"),
+					resource.TestCheckResourceAttr("clickhouseops_grantrole.onboarding", "with_admin_option", "true"),
+					resource.TestCheckResourceAttr("clickhouseops_grantrole.onboarding", "set_default", "false"),
+					resource.TestCheckResourceAttr("clickhouseops_grantrole.onboarding", "id", ":reader:user3,user4"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccGrantRolesConfig = `
+resource "clickhouseops_simpleuser" "user3" {
+	name = "user3"
+	sha256_password = sha256("password3")
+}
+
+resource "clickhouseops_simpleuser" "user4" {
+	name = "user4"
+	sha256_password = sha256("password4")
+}
+
+resource "clickhouseops_simplerole" "reader" {
+	name = "reader"
+}
+
+resource "clickhouseops_simplerole" "writer" {
+	name = "writer"
+}
+
+resource "clickhouseops_grantrole" "onboarding" {
+	role_names = [clickhouseops_simplerole.reader.name, clickhouseops_simplerole.writer.name]
+	grantees = [clickhouseops_simpleuser.user3.name, clickhouseops_simpleuser.user4.name]
+	set_default = true
+}
+`
+	testAccGrantRolesUpdatedConfig = `
+resource "clickhouseops_simpleuser" "user3" {
+	name = "user3"
+	sha256_password = sha256("password3")
+}
+
+resource "clickhouseops_simpleuser" "user4" {
+	name = "user4"
+	sha256_password = sha256("password4")
+}
+
+resource "clickhouseops_simplerole" "reader" {
+	name = "reader"
+}
+
+resource "clickhouseops_simplerole" "writer" {
+	name = "writer"
+}
+
+resource "clickhouseops_grantrole" "onboarding" {
+	role_names = [clickhouseops_simplerole.reader.name]
+	grantees = [clickhouseops_simpleuser.user3.name, clickhouseops_simpleuser.user4.name]
+	with_admin_option = true
+	set_default = false
+}
+`
+)
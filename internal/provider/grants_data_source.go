@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GrantsDataSource{}
+
+func NewGrantsDataSource() datasource.DataSource {
+	return &GrantsDataSource{}
+}
+
+// GrantsDataSource lists the privileges found in system.grants.
+type GrantsDataSource struct {
+	db clickhouse.Conn
+}
+
+type GrantsDataSourceModel struct {
+	Id           types.String     `tfsdk:"id"`
+	Assignee     types.String     `tfsdk:"assignee"`
+	DatabaseName types.String     `tfsdk:"database_name"`
+	TableName    types.String     `tfsdk:"table_name"`
+	Grants       []GrantDataModel `tfsdk:"grants"`
+}
+
+type GrantDataModel struct {
+	Assignee        types.String `tfsdk:"assignee"`
+	Privilege       types.String `tfsdk:"privilege"`
+	DatabaseName    types.String `tfsdk:"database_name"`
+	TableName       types.String `tfsdk:"table_name"`
+	Column          types.String `tfsdk:"column"`
+	IsPartialRevoke types.Bool   `tfsdk:"is_partial_revoke"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+}
+
+func (d *GrantsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grants"
+}
+
+func (d *GrantsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to look up privileges granted to users and roles from system.grants",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"assignee": schema.StringAttribute{
+				MarkdownDescription: "Only return privileges granted to this user or role",
+				Optional:            true,
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Only return privileges granted on this database",
+				Optional:            true,
+			},
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Only return privileges granted on this table",
+				Optional:            true,
+			},
+			"grants": schema.ListNestedAttribute{
+				MarkdownDescription: "Privileges ordered by assignee",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"assignee": schema.StringAttribute{
+							MarkdownDescription: "User or role the privilege is granted to",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege, ie. SELECT or INSERT",
+							Computed:            true,
+						},
+						"database_name": schema.StringAttribute{
+							MarkdownDescription: "Database the privilege applies to, null for every database",
+							Computed:            true,
+						},
+						"table_name": schema.StringAttribute{
+							MarkdownDescription: "Table the privilege applies to, null for every table",
+							Computed:            true,
+						},
+						"column": schema.StringAttribute{
+							MarkdownDescription: "Column the privilege applies to, null for every column",
+							Computed:            true,
+						},
+						"is_partial_revoke": schema.BoolAttribute{
+							MarkdownDescription: "Whether this entry revokes part of a wider privilege",
+							Computed:            true,
+						},
+						"with_grant_option": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege is granted WITH GRANT OPTION",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *GrantsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readGrantsDataQuery = `
+SELECT assignee, privilege, database, table, column, toBool(is_partial_revoke), toBool(grant_option)
+FROM (
+	SELECT ifNull(user_name, role_name) AS assignee, toString(access_type) AS privilege, database, table, column, is_partial_revoke, grant_option
+	FROM system.grants
+)`
+
+func (d *GrantsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GrantsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, args := filterQuery(readGrantsDataQuery,
+		queryFilter{Condition: "assignee = ?", Value: data.Assignee},
+		queryFilter{Condition: "database = ?", Value: data.DatabaseName},
+		queryFilter{Condition: "table = ?", Value: data.TableName},
+	)
+	rows, err := d.db.Query(ctx, query+" ORDER BY assignee, database, table, privilege, column", args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Grants",
+			"Could not read system.grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	data.Grants = nil
+	for rows.Next() {
+		var assignee, privilege string
+		var database, table, column *string
+		var isPartialRevoke, grantOption bool
+		if err := rows.Scan(&assignee, &privilege, &database, &table, &column, &isPartialRevoke, &grantOption); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Grants",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		data.Grants = append(data.Grants, GrantDataModel{
+			Assignee:        types.StringValue(assignee),
+			Privilege:       types.StringValue(privilege),
+			DatabaseName:    types.StringPointerValue(database),
+			TableName:       types.StringPointerValue(table),
+			Column:          types.StringPointerValue(column),
+			IsPartialRevoke: types.BoolValue(isPartialRevoke),
+			WithGrantOption: types.BoolValue(grantOption),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Grants",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(data.Assignee.ValueString() + ":" + data.DatabaseName.ValueString() + "." + data.TableName.ValueString())
+
+	tflog.Trace(ctx, "read grants data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
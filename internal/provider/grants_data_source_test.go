@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGrantsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrantsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_grants.lookup", "grants.#", "2"),
+					resource.TestCheckResourceAttr("data.clickhouseops_grants.lookup", "grants.0.assignee", "lookup_grants_role"),
+					resource.TestCheckResourceAttr("data.clickhouseops_grants.lookup", "grants.0.privilege", "SELECT"),
+					resource.TestCheckResourceAttr("data.clickhouseops_grants.lookup", "grants.0.column", "database"),
+					resource.TestCheckResourceAttr("data.clickhouseops_grants.lookup", "grants.1.column", "name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccGrantsDataSourceConfig = `
+resource "clickhouseops_simplerole" "lookup_grants_role" {
+  name = "lookup_grants_role"
+}
+
+resource "clickhouseops_grantselect" "lookup" {
+  database_name = "system"
+  table_name = "tables"
+  columns_name = ["database", "name"]
+  assignee = clickhouseops_simplerole.lookup_grants_role.name
+}
+
+data "clickhouseops_grants" "lookup" {
+  assignee = clickhouseops_simplerole.lookup_grants_role.name
+  database_name = "system"
+  table_name = "tables"
+  depends_on = [clickhouseops_grantselect.lookup]
+}
+`
@@ -147,11 +147,7 @@ func (r *GrantSelect) Create(ctx context.Context, req resource.CreateRequest, re
 		)
 		return
 	}
-	var columns []string
-	for _, v := range data.ColumnsName {
-		columns = append(columns, v.ValueString())
-	}
-	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + strings.Join(columns, ":") + ":" + data.Assignee.ValueString())
+	data.ID = types.StringValue(selectGrantID(data.ClusterName, data.DatabaseName, data.TableName, data.Assignee))
 
 	tflog.Trace(ctx, "Created a GrantSelect Resource")
 
@@ -169,6 +165,10 @@ func (r *GrantSelect) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	// States created before columns were updated in place hold IDs in the form cluster:columns:assignee,
+	// migrating here lets the plan carry the current ID into Update.
+	data.ID = types.StringValue(selectGrantID(data.ClusterName, data.DatabaseName, data.TableName, data.Assignee))
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -176,15 +176,52 @@ func (r *GrantSelect) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *GrantSelect) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *GrantSelectModel
+	var data, state *GrantSelectModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Removed columns are handled as a destroy, added columns as a create.
+	removed, destroy, added, create := selectColumnsDelta(state.ColumnsName, data.ColumnsName)
+	statements := []struct {
+		template string
+		columns  []types.String
+		run      bool
+	}{
+		{ddlDestroyGrantSelectTemplate, removed, destroy},
+		{ddlCreateGrantSelectTemplate, added, create},
+	}
+	for _, statement := range statements {
+		if !statement.run {
+			continue
+		}
+
+		delta := *data
+		delta.ColumnsName = statement.columns
+		query, err := common.RenderTemplate(statement.template, delta)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				"Could not render DDL, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		err = r.db.Exec(ctx, *query)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				"Could not execute DDL, unexpected error: "+*query+err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -216,5 +253,72 @@ func (r *GrantSelect) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *GrantSelect) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importSelectGrant(ctx, req, resp)
+}
+
+// importSelectGrant accepts an ID in the form [cluster_name:]database_name:table_name:assignee, table_name being *
+// for the whole database. Columns are not read back, they are taken from the configuration on the next apply.
+func importSelectGrant(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) == 3 {
+		parts = append([]string{""}, parts...)
+	}
+	if len(parts) != 4 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier with format: [cluster_name:]database_name:table_name:assignee, got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(parts, ":"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), parts[1])...)
+	if parts[2] != "*" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"), parts[2])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("assignee"), parts[3])...)
+	if parts[0] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), parts[0])...)
+	}
+}
+
+// selectGrantID returns the ID of a SELECT grant or revoke, unique per target and assignee.
+func selectGrantID(clusterName, databaseName, tableName, assignee types.String) string {
+	table := "*"
+	if !tableName.IsNull() {
+		table = tableName.ValueString()
+	}
+	return clusterName.ValueString() + ":" + databaseName.ValueString() + ":" + table + ":" + assignee.ValueString()
+}
+
+// selectColumnsDelta returns the columns removed from and added to a SELECT privilege, and whether a statement
+// is needed for each. Empty columns stand for the whole table.
+func selectColumnsDelta(current, planned []types.String) (removed []types.String, remove bool, added []types.String, add bool) {
+	switch {
+	case len(current) == 0 && len(planned) == 0:
+		return nil, false, nil, false
+	case len(current) == 0:
+		return nil, true, planned, true
+	case len(planned) == 0:
+		return nil, false, nil, true
+	}
+
+	removed = subtractColumns(current, planned)
+	added = subtractColumns(planned, current)
+	return removed, len(removed) > 0, added, len(added) > 0
+}
+
+func subtractColumns(from, other []types.String) []types.String {
+	exclude := map[string]bool{}
+	for _, column := range other {
+		exclude[column.ValueString()] = true
+	}
+
+	var result []types.String
+	for _, column := range from {
+		if !exclude[column.ValueString()] {
+			result = append(result, column)
+		}
+	}
+	return result
 }
@@ -4,8 +4,16 @@
 package provider
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -20,11 +28,91 @@ func TestAccGrantSelectResource(t *testing.T) {
 					resource.TestCheckResourceAttr("clickhouseops_grantselect.new_grant", "assignee", "user2"),
 				),
 			},
+			// Update columns in place
+			{
+				Config: testAccGrantSelectUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grantselect.new_grant", "columns_name.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_grantselect.new_grant", "id", ":system:tables:user2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "clickhouseops_grantselect.new_grant",
+				ImportState:             true,
+				ImportStateId:           "system:tables:user2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"columns_name"},
+			},
 		},
 	})
 }
 
-const testAccGrantSelectConfig = `
+func TestAccGrantSelectResourceLegacyID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			// Create with the ID format used before columns were updated in place
+			{
+				ProtoV6ProviderFactories: testAccLegacySelectIDProviderFactories,
+				Config:                   testAccGrantSelectConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grantselect.new_grant", "id", ":database:name:user2"),
+				),
+			},
+			// Update columns in place from the old ID
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config:                   testAccGrantSelectUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_grantselect.new_grant", "columns_name.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_grantselect.new_grant", "id", ":system:tables:user2"),
+				),
+			},
+		},
+	})
+}
+
+// testAccLegacySelectIDProviderFactories serve a provider whose grantselect and revokeselect resources store
+// IDs in the form cluster:columns:assignee, as they did before columns were updated in place.
+var testAccLegacySelectIDProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"clickhouseops": providerserver.NewProtocol6WithError(&legacySelectIDProvider{&ClickhouseProvider{version: "test"}}),
+}
+
+type legacySelectIDProvider struct {
+	*ClickhouseProvider
+}
+
+func (p *legacySelectIDProvider) Resources(ctx context.Context) []func() frameworkresource.Resource {
+	return []func() frameworkresource.Resource{
+		NewSimpleUser,
+		func() frameworkresource.Resource { return &legacySelectID{NewGrantSelect().(*GrantSelect)} },
+		func() frameworkresource.Resource { return &legacySelectID{NewRevokeSelect().(*RevokeSelect)} },
+	}
+}
+
+var _ provider.Provider = &legacySelectIDProvider{}
+
+type legacySelectID struct {
+	frameworkresource.ResourceWithConfigure
+}
+
+func (r *legacySelectID) Create(ctx context.Context, req frameworkresource.CreateRequest, resp *frameworkresource.CreateResponse) {
+	r.ResourceWithConfigure.Create(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var clusterName, assignee types.String
+	var columns []string
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("cluster_name"), &clusterName)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("columns_name"), &columns)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("assignee"), &assignee)...)
+	id := clusterName.ValueString() + ":" + strings.Join(columns, ":") + ":" + assignee.ValueString()
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+const (
+	testAccGrantSelectConfig = `
 resource "clickhouseops_simpleuser" "user2" {
 	name = "user2"
 	sha256_password = sha256("password2")
@@ -42,3 +130,22 @@ resource "clickhouseops_grantselect" "new_grant_all" {
 	assignee = clickhouseops_simpleuser.user2.name
 }
 `
+	testAccGrantSelectUpdatedConfig = `
+resource "clickhouseops_simpleuser" "user2" {
+	name = "user2"
+	sha256_password = sha256("password2")
+}
+
+resource "clickhouseops_grantselect" "new_grant" {
+	database_name = "system"
+	table_name = "tables"
+	columns_name = ["database", "engine"]
+	assignee = clickhouseops_simpleuser.user2.name
+}
+
+resource "clickhouseops_grantselect" "new_grant_all" {
+	database_name = "system"
+	assignee = clickhouseops_simpleuser.user2.name
+}
+`
+)
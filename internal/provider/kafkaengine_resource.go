@@ -22,6 +22,7 @@ var (
 	_ resource.Resource                = &KafkaEngineResource{}
 	_ resource.ResourceWithConfigure   = &KafkaEngineResource{}
 	_ resource.ResourceWithImportState = &KafkaEngineResource{}
+	_ resource.ResourceWithModifyPlan  = &KafkaEngineResource{}
 )
 
 func NewKafkaEngineResource() resource.Resource {
@@ -29,7 +30,8 @@ func NewKafkaEngineResource() resource.Resource {
 }
 
 type KafkaEngineResource struct {
-	db clickhouse.Conn
+	db     clickhouse.Conn
+	server *ServerInfo
 }
 
 type KafkaEngineResourceModel struct {
@@ -167,6 +169,16 @@ func (r *KafkaEngineResource) Configure(ctx context.Context, req resource.Config
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+}
+
+// ModifyPlan fails the plan when the server was built without Kafka.
+func (r *KafkaEngineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkFeature(featureKafka)...)
 }
 
 const ddlCreateKakfaTemplate = `
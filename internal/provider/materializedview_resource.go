@@ -6,8 +6,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,9 +21,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &MaterializedView{}
-	_ resource.ResourceWithConfigure   = &MaterializedView{}
-	_ resource.ResourceWithImportState = &MaterializedView{}
+	_ resource.Resource                   = &MaterializedView{}
+	_ resource.ResourceWithConfigure      = &MaterializedView{}
+	_ resource.ResourceWithImportState    = &MaterializedView{}
+	_ resource.ResourceWithValidateConfig = &MaterializedView{}
+	_ resource.ResourceWithModifyPlan     = &MaterializedView{}
 )
 
 func NewMaterializedView() resource.Resource {
@@ -29,17 +33,25 @@ func NewMaterializedView() resource.Resource {
 }
 
 type MaterializedView struct {
-	db clickhouse.Conn
+	db     clickhouse.Conn
+	server *ServerInfo
 }
 
 type MaterializedViewModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	DatabaseName       types.String `tfsdk:"database_name"`
-	ClusterName        types.String `tfsdk:"cluster_name"`
-	TargetDatabaseName types.String `tfsdk:"target_database_name"`
-	TargetTableName    types.String `tfsdk:"target_table_name"`
-	SQL                types.String `tfsdk:"sql"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	DatabaseName       types.String   `tfsdk:"database_name"`
+	ClusterName        types.String   `tfsdk:"cluster_name"`
+	TargetDatabaseName types.String   `tfsdk:"target_database_name"`
+	TargetTableName    types.String   `tfsdk:"target_table_name"`
+	SQL                types.String   `tfsdk:"sql"`
+	AutoCreateTarget   types.Bool     `tfsdk:"auto_create_target"`
+	TargetOrderBy      []types.String `tfsdk:"target_order_by"`
+	TargetPartitionBy  types.String   `tfsdk:"target_partition_by"`
+	TargetColumns      types.List     `tfsdk:"target_columns"`
+	Definer            types.String   `tfsdk:"definer"`
+	SQLSecurity        types.String   `tfsdk:"sql_security"`
+	Comment            types.String   `tfsdk:"comment"`
 }
 
 func (r *MaterializedView) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -96,7 +108,76 @@ func (r *MaterializedView) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "Clickhouse Name Collection Name",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessAutoCreateTarget,
+						"Replace the view unless the target table is managed by the view",
+						"Replace the view unless the target table is managed by the view",
+					),
+				},
+			},
+			"auto_create_target": schema.BoolAttribute{
+				MarkdownDescription: "If true the target table is created as a MergeTree using the columns returned by `DESCRIBE` on `sql`, creating the view fails when the table already exists. " +
+					"When `sql` changes, new columns are added and changed types modified before the view query is replaced, columns are never dropped. " +
+					"The target table is dropped with the view, changes replacing the view are refused while it is set. " +
+					"Turning it off releases the table, which is then kept, it can not be turned on for an existing view",
+				Optional: true,
+			},
+			"target_order_by": schema.ListAttribute{
+				MarkdownDescription: "Clickhouse columns list for order by of the auto created target table, changed in place with `MODIFY ORDER BY`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"target_partition_by": schema.StringAttribute{
+				MarkdownDescription: "Partition expression of the auto created target table, it can not be changed once the table is created",
+				Optional:            true,
+			},
+			"definer": schema.StringAttribute{
+				MarkdownDescription: "User used to execute the view query when `sql_security` is `DEFINER`, ie. a username or `CURRENT_USER`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessAutoCreateTarget,
+						"Replace the view unless the target table is managed by the view",
+						"Replace the view unless the target table is managed by the view",
+					),
+				},
+			},
+			"sql_security": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse materialized view SQL security, one of `DEFINER` or `NONE`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessAutoCreateTarget,
+						"Replace the view unless the target table is managed by the view",
+						"Replace the view unless the target table is managed by the view",
+					),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse materialized view comment",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessAutoCreateTarget,
+						"Replace the view unless the target table is managed by the view",
+						"Replace the view unless the target table is managed by the view",
+					),
+				},
+			},
+			"target_columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Columns inferred from `sql` for the auto created target table",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Column name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type",
+							Computed:            true,
+						},
+					},
 				},
 			},
 		},
@@ -120,16 +201,23 @@ func (r *MaterializedView) Configure(ctx context.Context, req resource.Configure
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
 }
 
 /*
 	Clickhouse MaterializedView Syntax for reference
 
-CREATE MATERIALIZED VIEW [IF NOT EXISTS] [db.]table_name [ON CLUSTER] [TO[db.]name] [ENGINE = engine] [POPULATE] AS SELECT ...
+CREATE MATERIALIZED VIEW [IF NOT EXISTS] [db.]table_name [ON CLUSTER] [TO[db.]name] [ENGINE = engine] [POPULATE]
+[DEFINER = { user | CURRENT_USER }] [SQL SECURITY { DEFINER | NONE }]
+AS SELECT ...
+[COMMENT 'comment']
 */
 const ddlCreateMaterializedViewTemplate = `
 CREATE MATERIALIZED VIEW IF NOT EXISTS "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} TO "{{.TargetDatabaseName.ValueString}}"."{{.TargetTableName.ValueString}}"
+{{if not .Definer.IsNull}}DEFINER = {{definer .Definer.ValueString}}{{end}}
+{{if not .SQLSecurity.IsNull}}SQL SECURITY {{.SQLSecurity.ValueString}}{{end}}
 AS {{.SQL.ValueString}}
+{{if not .Comment.IsNull}}COMMENT '{{escape .Comment.ValueString}}'{{end}}
 `
 
 /*
@@ -140,29 +228,161 @@ const ddlDropMaterializedViewTemplate = `
 DROP VIEW IF EXISTS "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
 `
 
-func (r *MaterializedView) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *MaterializedViewModel
+const describeMaterializedViewQueryTemplate = `DESCRIBE ({{.SQL.ValueString}})`
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+const readTargetColumnsQuery = `SELECT name, type FROM system.columns WHERE database = ? AND table = ? ORDER BY position`
+
+/*
+ALTER TABLE [db.]table [ON CLUSTER cluster] ADD|MODIFY COLUMN ..., MODIFY ORDER BY new_expression
+.
+*/
+const ddlAlterTargetColumnsTemplate = `
+ALTER TABLE "{{.DatabaseName}}"."{{.Name}}"{{if .ClusterName}} ON CLUSTER '{{.ClusterName}}'{{end}}
+{{$size := size .Actions}}{{range $i, $e := .Actions}}{{$e}}{{if lt $i $size}},{{end}}
+{{end}}
+`
+
+/*
+ALTER TABLE [db.]view [ON CLUSTER cluster] MODIFY QUERY select_statement
+ALTER TABLE [db.]view [ON CLUSTER cluster] MODIFY SQL SECURITY { DEFINER | NONE } [DEFINER = { user | CURRENT_USER }]
+ALTER TABLE [db.]view [ON CLUSTER cluster] MODIFY COMMENT 'comment'
+.
+*/
+const ddlAlterMaterializedViewTemplate = `
+ALTER TABLE "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{.Action}}
+`
+
+const ddlDropTargetTableTemplate = `
+DROP TABLE IF EXISTS "{{.TargetDatabaseName.ValueString}}"."{{.TargetTableName.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+`
+
+// AlterMaterializedView renders a single ALTER action on the view, a MODIFY QUERY can not be followed by another one.
+type AlterMaterializedView struct {
+	*MaterializedViewModel
+	Action string
+}
+
+type AlterTargetColumns struct {
+	DatabaseName string
+	Name         string
+	ClusterName  string
+	Actions      []string
+}
+
+func requiresReplaceUnlessAutoCreateTarget(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var autoCreateTarget types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auto_create_target"), &autoCreateTarget)...)
+	resp.RequiresReplace = !autoCreateTarget.ValueBool()
+}
+
+func (r *MaterializedView) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data MaterializedViewModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlCreateMaterializedViewTemplate, data)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Clickhouse MaterializedView",
-			"Could not render DDL, unexpected error: "+err.Error(),
+	if !data.SQLSecurity.IsNull() && !data.SQLSecurity.IsUnknown() {
+		switch strings.ToUpper(data.SQLSecurity.ValueString()) {
+		case "DEFINER", "NONE":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sql_security"),
+				"Invalid Attribute Configuration",
+				"Expect sql_security to be one of DEFINER or NONE, got: "+data.SQLSecurity.ValueString(),
+			)
+		}
+	}
+
+	if data.AutoCreateTarget.ValueBool() && len(data.TargetOrderBy) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("target_order_by"),
+			"Missing Attribute Configuration",
+			"Expect target_order_by to be defined when auto_create_target is true",
 		)
+	}
+
+	if !data.SQL.IsUnknown() && viewParameterRegexp.MatchString(stripLiterals(data.SQL.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sql"),
+			"Invalid Attribute Configuration",
+			"Materialized views do not support query parameters, remove the {name:Type} placeholders from sql",
+		)
+	}
+}
+
+// ModifyPlan fails the plan when definer or sql_security is set and the server does not support them, and refuses
+// the changes which would drop the target table managed by the view, or adopt one it did not create.
+func (r *MaterializedView) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	err = r.db.Exec(ctx, *query)
-	if err != nil {
+	var data, state MaterializedViewModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Definer.IsNull() || !data.SQLSecurity.IsNull() {
+		resp.Diagnostics.Append(r.server.checkFeature(featureSQLSecurity)...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.AutoCreateTarget.ValueBool() {
+		if data.AutoCreateTarget.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auto_create_target"),
+				"Invalid Attribute Configuration",
+				"auto_create_target can not be turned on for an existing view, the target table was not created by it",
+			)
+		}
+		return
+	}
+
+	if len(resp.RequiresReplace) > 0 {
 		resp.Diagnostics.AddError(
-			"Error Creating Clickhouse MaterializedView",
-			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+			"Invalid Attribute Configuration",
+			"The change replaces the view, which drops the target table created by it and its data. "+
+				"Set auto_create_target to false and apply first to release the table",
+		)
+	}
+	if data.AutoCreateTarget.ValueBool() && !data.TargetPartitionBy.Equal(state.TargetPartitionBy) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("target_partition_by"),
+			"Invalid Attribute Configuration",
+			"target_partition_by can not be changed once the target table is created",
 		)
+	}
+}
+
+func (r *MaterializedView) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *MaterializedViewModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.TargetColumns = types.ListNull(types.ObjectType{AttrTypes: clickhouseColumnAttrTypes})
+	if data.AutoCreateTarget.ValueBool() {
+		resp.Diagnostics.Append(r.syncTargetTable(ctx, data, nil)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.createMaterializedView(ctx, data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -191,15 +411,71 @@ func (r *MaterializedView) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *MaterializedView) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *MaterializedViewModel
+	var data, state *MaterializedViewModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.TargetColumns = state.TargetColumns
+	if !data.AutoCreateTarget.ValueBool() {
+		// Turning auto_create_target off releases the target table, which is kept.
+		data.TargetColumns = types.ListNull(types.ObjectType{AttrTypes: clickhouseColumnAttrTypes})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Target columns are added or widened first so the running view keeps inserting, then the view is altered
+	// in place, which keeps it attached to the target table without a window where inserts are lost.
+	var actions []string
+	if !data.SQL.Equal(state.SQL) || !equalValues(data.TargetOrderBy, state.TargetOrderBy) {
+		resp.Diagnostics.Append(r.syncTargetTable(ctx, data, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.SQL.Equal(state.SQL) {
+		actions = append(actions, "MODIFY QUERY "+data.SQL.ValueString())
+	}
+	if !data.SQLSecurity.Equal(state.SQLSecurity) || !data.Definer.Equal(state.Definer) {
+		// DEFINER is the server default for materialized views.
+		security := "MODIFY SQL SECURITY DEFINER"
+		if !data.SQLSecurity.IsNull() {
+			security = "MODIFY SQL SECURITY " + strings.ToUpper(data.SQLSecurity.ValueString())
+		}
+		if !data.Definer.IsNull() {
+			security += " DEFINER = " + common.Definer(data.Definer.ValueString())
+		}
+		actions = append(actions, security)
+	}
+	if !data.Comment.Equal(state.Comment) {
+		actions = append(actions, "MODIFY COMMENT '"+common.EscapeString(data.Comment.ValueString())+"'")
+	}
+
+	for _, action := range actions {
+		query, err := common.RenderTemplate(ddlAlterMaterializedViewTemplate, AlterMaterializedView{MaterializedViewModel: data, Action: action})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Clickhouse MaterializedView",
+				"Could not render DDL, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		err = r.db.Exec(ctx, *query)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Clickhouse MaterializedView",
+				"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -224,8 +500,205 @@ func (r *MaterializedView) Delete(ctx context.Context, req resource.DeleteReques
 		resp.Diagnostics.AddError("", ""+err.Error())
 		return
 	}
+
+	if data.AutoCreateTarget.ValueBool() {
+		query, err := common.RenderTemplate(ddlDropTargetTableTemplate, data)
+		if err != nil {
+			resp.Diagnostics.AddError("", ""+err.Error())
+			return
+		}
+
+		err = r.db.Exec(ctx, *query)
+		if err != nil {
+			resp.Diagnostics.AddError("", ""+err.Error())
+			return
+		}
+	}
 }
 
 func (r *MaterializedView) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func (r *MaterializedView) createMaterializedView(ctx context.Context, data *MaterializedViewModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	query, err := common.RenderTemplate(ddlCreateMaterializedViewTemplate, data)
+	if err != nil {
+		diags.AddError(
+			"Error Creating Clickhouse MaterializedView",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		diags.AddError(
+			"Error Creating Clickhouse MaterializedView",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+	}
+	return diags
+}
+
+// syncTargetTable infers the columns of the view query and creates the target table, failing when it already
+// exists unless state is set, ie. the table was created by the view. Otherwise missing columns are added, changed
+// types modified and the sorting key changed in the same ALTER, as the new key columns must be added by it.
+// Columns the query no longer returns are kept. The inferred columns are stored in TargetColumns.
+func (r *MaterializedView) syncTargetTable(ctx context.Context, data *MaterializedViewModel, state *MaterializedViewModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	query, err := common.RenderTemplate(describeMaterializedViewQueryTemplate, data)
+	if err != nil {
+		diags.AddError(
+			"Error Inferring Clickhouse MaterializedView Target Columns",
+			"Could not render DESCRIBE, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	described, err := describeColumns(ctx, r.db, *query)
+	if err != nil {
+		diags.AddError(
+			"Error Inferring Clickhouse MaterializedView Target Columns",
+			"Could not execute DESCRIBE: "+*query+", unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	var columns []ClickhouseColumn
+	var targetColumns []MergeTreeColumnsModel
+	for _, col := range described {
+		columns = append(columns, ClickhouseColumn{
+			Name: types.StringValue(col.Name),
+			Type: types.StringValue(col.Type),
+		})
+		targetColumns = append(targetColumns, MergeTreeColumnsModel{
+			Name: types.StringValue(col.Name),
+			Type: types.StringValue(col.Type),
+		})
+	}
+
+	existing, err := r.readTargetColumns(ctx, data)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Clickhouse MaterializedView Target Table",
+			"Could not read columns, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	if len(existing) > 0 && state == nil {
+		diags.AddAttributeError(
+			path.Root("target_table_name"),
+			"Error Creating Clickhouse MaterializedView Target Table",
+			"Table "+data.TargetDatabaseName.ValueString()+"."+data.TargetTableName.ValueString()+" already exists, "+
+				"auto_create_target only manages tables created by the view. Set auto_create_target to false to write into an existing table",
+		)
+		return diags
+	}
+
+	var actions []string
+	if len(existing) == 0 {
+		query, err = common.RenderTemplate(ddlCreateMergeTreeTemplate, MergeTreeResourceModel{
+			Name:         data.TargetTableName,
+			DatabaseName: data.TargetDatabaseName,
+			ClusterName:  data.ClusterName,
+			Columns:      targetColumns,
+			OrderBy:      data.TargetOrderBy,
+			PartitionBy:  data.TargetPartitionBy,
+			PrimaryKey:   types.StringNull(),
+		})
+	} else {
+		actions = alterTargetColumnsActions(existing, described)
+		if !equalValues(data.TargetOrderBy, state.TargetOrderBy) {
+			var orderBy []string
+			for _, col := range data.TargetOrderBy {
+				orderBy = append(orderBy, fmt.Sprintf("\"%s\"", col.ValueString()))
+			}
+			actions = append(actions, "MODIFY ORDER BY ("+strings.Join(orderBy, ",")+")")
+		}
+		query, err = common.RenderTemplate(ddlAlterTargetColumnsTemplate, AlterTargetColumns{
+			DatabaseName: data.TargetDatabaseName.ValueString(),
+			Name:         data.TargetTableName.ValueString(),
+			ClusterName:  data.ClusterName.ValueString(),
+			Actions:      actions,
+		})
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Creating Clickhouse MaterializedView Target Table",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	if len(existing) == 0 || len(actions) > 0 {
+		err = r.db.Exec(ctx, *query)
+		if err != nil {
+			diags.AddError(
+				"Error Creating Clickhouse MaterializedView Target Table",
+				"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+	}
+
+	var listDiags diag.Diagnostics
+	data.TargetColumns, listDiags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: clickhouseColumnAttrTypes}, columns)
+	diags.Append(listDiags...)
+	return diags
+}
+
+func (r *MaterializedView) readTargetColumns(ctx context.Context, data *MaterializedViewModel) ([]Column, error) {
+	rows, err := r.db.Query(ctx, readTargetColumnsQuery, data.TargetDatabaseName.ValueString(), data.TargetTableName.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []Column
+	for rows.Next() {
+		var col Column
+		if err := rows.Scan(&col.Name, &col.Type); err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
+
+// alterTargetColumnsActions returns the ALTER actions adding the desired columns missing from the existing ones and
+// modifying those whose type changed. Existing columns not desired are kept, dropping them would lose data.
+func alterTargetColumnsActions(existing []Column, desired []Column) []string {
+	current := make(map[string]string, len(existing))
+	for _, col := range existing {
+		current[col.Name] = col.Type
+	}
+
+	var actions []string
+	for _, col := range desired {
+		currentType, ok := current[col.Name]
+		switch {
+		case !ok:
+			actions = append(actions, fmt.Sprintf("ADD COLUMN IF NOT EXISTS \"%s\" %s", col.Name, col.Type))
+		case currentType != col.Type:
+			actions = append(actions, fmt.Sprintf("MODIFY COLUMN \"%s\" %s", col.Name, col.Type))
+		}
+	}
+	return actions
+}
+
+// equalValues reports whether both lists hold the same values in the same order.
+func equalValues(a, b []types.String) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
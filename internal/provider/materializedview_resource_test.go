@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -25,6 +26,47 @@ func TestAccMaterializedViewResource(t *testing.T) {
 	})
 }
 
+func TestAccMaterializedViewAutoCreateTargetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMaterializedViewAutoCreateTargetConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.#", "1"),
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.0.name", "id"),
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.0.type", "UInt64"),
+				),
+			},
+			// Update columns and sorting key in place
+			{
+				Config: testAccMaterializedViewAutoCreateTargetUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.1.name", "name"),
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.1.type", "String"),
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "target_order_by.#", "2"),
+				),
+			},
+			// Replacing the view would drop the target table
+			{
+				Config:      testAccMaterializedViewAutoCreateTargetRenamedConfig,
+				ExpectError: regexp.MustCompile(`release the table`),
+			},
+			// Release the target table
+			{
+				Config: testAccMaterializedViewAutoCreateTargetReleasedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_materializedview.auto_view", "auto_create_target", "false"),
+					resource.TestCheckNoResourceAttr("clickhouseops_materializedview.auto_view", "target_columns.#"),
+				),
+			},
+		},
+	})
+}
+
 const testAccMaterializedViewResourceConfig = `
 resource "clickhouseops_database" "source" {
 	name = "source"
@@ -44,3 +86,74 @@ SELECT 1
 EOT
 }
 `
+
+const (
+	testAccMaterializedViewAutoCreateTargetConfig = `
+resource "clickhouseops_database" "auto_source" {
+	name = "auto_source"
+}
+
+resource "clickhouseops_materializedview" "auto_view" {
+	name = "auto_view"
+	database_name = clickhouseops_database.auto_source.name
+	target_database_name = clickhouseops_database.auto_source.name
+	target_table_name = "auto_target"
+	auto_create_target = true
+	target_order_by = ["id"]
+	sql = <<EOT
+SELECT toUInt64(1) AS id
+EOT
+}
+`
+	testAccMaterializedViewAutoCreateTargetUpdatedConfig = `
+resource "clickhouseops_database" "auto_source" {
+	name = "auto_source"
+}
+
+resource "clickhouseops_materializedview" "auto_view" {
+	name = "auto_view"
+	database_name = clickhouseops_database.auto_source.name
+	target_database_name = clickhouseops_database.auto_source.name
+	target_table_name = "auto_target"
+	auto_create_target = true
+	target_order_by = ["id", "name"]
+	sql = <<EOT
+SELECT toUInt64(1) AS id, 'name' AS name
+EOT
+}
+`
+	testAccMaterializedViewAutoCreateTargetRenamedConfig = `
+resource "clickhouseops_database" "auto_source" {
+	name = "auto_source"
+}
+
+resource "clickhouseops_materializedview" "auto_view" {
+	name = "auto_view_renamed"
+	database_name = clickhouseops_database.auto_source.name
+	target_database_name = clickhouseops_database.auto_source.name
+	target_table_name = "auto_target"
+	auto_create_target = true
+	target_order_by = ["id", "name"]
+	sql = <<EOT
+SELECT toUInt64(1) AS id, 'name' AS name
+EOT
+}
+`
+	testAccMaterializedViewAutoCreateTargetReleasedConfig = `
+resource "clickhouseops_database" "auto_source" {
+	name = "auto_source"
+}
+
+resource "clickhouseops_materializedview" "auto_view" {
+	name = "auto_view"
+	database_name = clickhouseops_database.auto_source.name
+	target_database_name = clickhouseops_database.auto_source.name
+	target_table_name = "auto_target"
+	auto_create_target = false
+	target_order_by = ["id", "name"]
+	sql = <<EOT
+SELECT toUInt64(1) AS id, 'name' AS name
+EOT
+}
+`
+)
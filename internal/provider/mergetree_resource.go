@@ -24,6 +24,7 @@ var (
 	_ resource.Resource                = &MergeTreeResource{}
 	_ resource.ResourceWithConfigure   = &MergeTreeResource{}
 	_ resource.ResourceWithImportState = &MergeTreeResource{}
+	_ resource.ResourceWithModifyPlan  = &MergeTreeResource{}
 )
 
 func NewMergeTreeResource() resource.Resource {
@@ -31,7 +32,8 @@ func NewMergeTreeResource() resource.Resource {
 }
 
 type MergeTreeResource struct {
-	db clickhouse.Conn
+	db     clickhouse.Conn
+	server *ServerInfo
 }
 
 type MergeTreeResourceModel struct {
@@ -177,17 +179,19 @@ func (r *MergeTreeResource) Configure(ctx context.Context, req resource.Configur
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
 }
 
-func (r *MergeTreeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *MergeTreeResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
+// ModifyPlan fails the plan when a setting is not known by the server.
+func (r *MergeTreeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	queryTemplate := `
+	resp.Diagnostics.Append(r.server.checkSettings(ctx, req.Plan, true)...)
+}
+
+const ddlCreateMergeTreeTemplate = `
 	CREATE TABLE "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}" {{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} 
 	(
 		{{range .Columns}}
@@ -204,8 +208,17 @@ func (r *MergeTreeResource) Create(ctx context.Context, req resource.CreateReque
 	{{.Name.ValueString}}='{{.Value.ValueString}}'{{if lt $i $size}},{{end}}
 	{{end}}
 	{{end}}
-	`
-	query, err := common.RenderTemplate(queryTemplate, data)
+`
+
+func (r *MergeTreeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *MergeTreeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateMergeTreeTemplate, data)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse MergeTree Table",
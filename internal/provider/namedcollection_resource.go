@@ -5,13 +5,17 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,9 +24,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &NamedCollection{}
-	_ resource.ResourceWithConfigure   = &NamedCollection{}
-	_ resource.ResourceWithImportState = &NamedCollection{}
+	_ resource.Resource                   = &NamedCollection{}
+	_ resource.ResourceWithConfigure      = &NamedCollection{}
+	_ resource.ResourceWithImportState    = &NamedCollection{}
+	_ resource.ResourceWithValidateConfig = &NamedCollection{}
+	_ resource.ResourceWithModifyPlan     = &NamedCollection{}
 )
 
 func NewNamedCollection() resource.Resource {
@@ -30,15 +36,19 @@ func NewNamedCollection() resource.Resource {
 }
 
 type NamedCollection struct {
-	db clickhouse.Conn
+	db      clickhouse.Conn
+	server  *ServerInfo
+	secrets *Secrets
 }
 
 type NamedCollectionModel struct {
-	ID                     types.String         `tfsdk:"id"`
-	Name                   types.String         `tfsdk:"name"`
-	ClusterName            types.String         `tfsdk:"cluster_name"`
-	KeyValuePairs          []KeyValuePairsModel `tfsdk:"keyvaluepairs"`
-	SensitiveKeyValuePairs []KeyValuePairsModel `tfsdk:"sensitive_keyvaluepairs"`
+	ID                     types.String                  `tfsdk:"id"`
+	Name                   types.String                  `tfsdk:"name"`
+	ClusterName            types.String                  `tfsdk:"cluster_name"`
+	Preset                 types.String                  `tfsdk:"preset"`
+	KeyValuePairs          []KeyValuePairsModel          `tfsdk:"keyvaluepairs"`
+	SensitiveKeyValuePairs []SensitiveKeyValuePairsModel `tfsdk:"sensitive_keyvaluepairs"`
+	SensitiveHash          types.String                  `tfsdk:"sensitive_keyvaluepairs_hash"`
 }
 
 type KeyValuePairsModel struct {
@@ -47,13 +57,22 @@ type KeyValuePairsModel struct {
 	IsNotOverridable types.Bool   `tfsdk:"is_not_overridable"`
 }
 
+type SensitiveKeyValuePairsModel struct {
+	Key              types.String `tfsdk:"key"`
+	Value            types.String `tfsdk:"value"`
+	Secret           types.String `tfsdk:"secret"`
+	IsNotOverridable types.Bool   `tfsdk:"is_not_overridable"`
+}
+
 func (r *NamedCollection) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_namedcollection"
 }
 
 func (r *NamedCollection) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Clickhouse Named Collection to store secret",
+		MarkdownDescription: "Clickhouse Named Collection to store secret. Changes made outside Terraform to values are only detected when the server " +
+			"displays secrets, ie. `display_secrets_in_show_and_select` is enabled and the user is granted `displaySecretsInShowAndSelect`, " +
+			"otherwise values read as `[HIDDEN]` keep their state value. Keys added outside Terraform are read into `sensitive_keyvaluepairs` without their value",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -76,6 +95,11 @@ func (r *NamedCollection) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"preset": schema.StringAttribute{
+				MarkdownDescription: "Table engine the collection is written for, one of kafka, s3, postgresql or mysql. " +
+					"Required keys and key names are validated at plan time",
+				Optional: true,
+			},
 			"keyvaluepairs": schema.ListNestedAttribute{
 				MarkdownDescription: "Clickhouse Named Collection Key-Value Pairs",
 				Required:            true,
@@ -90,14 +114,11 @@ func (r *NamedCollection) Schema(ctx context.Context, req resource.SchemaRequest
 							Required:            true,
 						},
 						"is_not_overridable": schema.BoolAttribute{
-							MarkdownDescription: "Clickhouse Named Collection Disable Overridden",
+							MarkdownDescription: "Renders NOT OVERRIDABLE when true and OVERRIDABLE when false, the server default applies when not set",
 							Optional:            true,
 						},
 					},
 				},
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
-				},
 			},
 			"sensitive_keyvaluepairs": schema.ListNestedAttribute{
 				MarkdownDescription: "Clickhouse Named Collection Sensitive Key-Value Pairs",
@@ -110,18 +131,25 @@ func (r *NamedCollection) Schema(ctx context.Context, req resource.SchemaRequest
 							Required:            true,
 						},
 						"value": schema.StringAttribute{
-							MarkdownDescription: "Clickhouse Named Collection Value",
-							Required:            true,
+							MarkdownDescription: "Clickhouse Named Collection Value, stored in state. Conflicts with secret",
+							Optional:            true,
+						},
+						"secret": schema.StringAttribute{
+							MarkdownDescription: "Name of the provider secret holding the value, which is not stored in state. Conflicts with value",
+							Optional:            true,
 						},
 						"is_not_overridable": schema.BoolAttribute{
-							MarkdownDescription: "Clickhouse Named Collection Disable Overridden",
+							MarkdownDescription: "Renders NOT OVERRIDABLE when true and OVERRIDABLE when false, the server default applies when not set",
 							Optional:            true,
 						},
 					},
 				},
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
-				},
+			},
+			"sensitive_keyvaluepairs_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the sensitive key-value pairs, used to detect changes made outside Terraform. " +
+					"Requires `display_secrets_in_show_and_select` enabled on the server and the `displaySecretsInShowAndSelect` grant, " +
+					"otherwise it keeps its state value",
+				Computed: true,
 			},
 		},
 	}
@@ -144,6 +172,201 @@ func (r *NamedCollection) Configure(ctx context.Context, req resource.ConfigureR
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+	r.secrets = providerSecrets(req.ProviderData)
+}
+
+// namedCollectionPreset lists the keys a table engine reads from a named collection.
+type namedCollectionPreset struct {
+	// Required holds groups of aliases, a group is satisfied when any of its keys is set.
+	Required [][]string
+	Optional []string
+	// Prefixes accepts any key starting with one of them, ie. settings passed through to the engine.
+	Prefixes []string
+}
+
+var namedCollectionPresets = map[string]namedCollectionPreset{
+	"kafka": {
+		Required: [][]string{{"kafka_broker_list"}},
+		Optional: []string{
+			"kafka_topic_list", "kafka_group_name", "kafka_format", "kafka_security_protocol", "kafka_sasl_mechanism",
+			"kafka_sasl_username", "kafka_sasl_password", "kafka_schema", "kafka_num_consumers", "kafka_max_block_size",
+			"kafka_skip_broken_messages", "kafka_commit_every_batch", "kafka_client_id", "kafka_poll_timeout_ms",
+			"kafka_poll_max_batch_size", "kafka_flush_interval_ms", "kafka_thread_per_consumer", "kafka_handle_error_mode",
+			"kafka_commit_on_select", "kafka_max_rows_per_message",
+		},
+		Prefixes: []string{"kafka.", "consumer.", "producer."},
+	},
+	"s3": {
+		Required: [][]string{{"url"}},
+		Optional: []string{
+			"access_key_id", "secret_access_key", "session_token", "use_environment_credentials", "no_sign_request",
+			"region", "format", "compression", "compression_method", "structure", "filename", "max_single_read_retries",
+			"min_upload_part_size", "upload_part_size_multiply_factor", "upload_part_size_multiply_parts_count_threshold",
+			"max_single_part_upload_size", "max_connections", "expiration_window_seconds",
+			"mode", "after_processing", "keeper_path",
+		},
+		Prefixes: []string{"s3queue_"},
+	},
+	"postgresql": {
+		Required: [][]string{{"host", "hostname", "addresses_expr"}, {"user", "username"}, {"password"}, {"database", "db"}},
+		Optional: []string{"port", "schema", "table", "on_conflict", "use_table_cache"},
+	},
+	"mysql": {
+		Required: [][]string{{"host", "hostname", "addresses_expr"}, {"user", "username"}, {"password"}, {"database", "db"}},
+		Optional: []string{
+			"port", "table", "replace_query", "on_duplicate_clause", "connection_pool_size", "connection_max_tries",
+			"connection_wait_timeout", "connection_auto_close", "connect_timeout", "read_write_timeout",
+		},
+	},
+}
+
+// accepts reports whether key is a known key of the preset.
+func (p namedCollectionPreset) accepts(key string) bool {
+	for _, group := range p.Required {
+		for _, alias := range group {
+			if key == alias {
+				return true
+			}
+		}
+	}
+	for _, optional := range p.Optional {
+		if key == optional {
+			return true
+		}
+	}
+	for _, prefix := range p.Prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *NamedCollection) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NamedCollectionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, pair := range data.SensitiveKeyValuePairs {
+		if pair.Value.IsNull() == pair.Secret.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sensitive_keyvaluepairs").AtListIndex(i),
+				"Invalid Attribute Configuration",
+				"Expect exactly one of value or secret to be set",
+			)
+		}
+	}
+
+	if data.Preset.IsNull() || data.Preset.IsUnknown() {
+		return
+	}
+	preset, ok := namedCollectionPresets[data.Preset.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preset"),
+			"Invalid Attribute Configuration",
+			"Expect preset to be one of kafka, s3, postgresql or mysql, got: "+data.Preset.ValueString(),
+		)
+		return
+	}
+
+	keys := map[string]bool{}
+	complete := true
+	lists := [][]types.String{{}, {}}
+	for _, pair := range data.KeyValuePairs {
+		lists[0] = append(lists[0], pair.Key)
+	}
+	for _, pair := range data.SensitiveKeyValuePairs {
+		lists[1] = append(lists[1], pair.Key)
+	}
+	for l, attribute := range []string{"keyvaluepairs", "sensitive_keyvaluepairs"} {
+		for i, key := range lists[l] {
+			if key.IsUnknown() {
+				complete = false
+				continue
+			}
+			keys[key.ValueString()] = true
+			if !preset.accepts(key.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute).AtListIndex(i).AtName("key"),
+					"Invalid Attribute Configuration",
+					"Expect key to be a "+data.Preset.ValueString()+" named collection key, got: "+key.ValueString(),
+				)
+			}
+		}
+	}
+	if !complete {
+		return
+	}
+
+	for _, group := range preset.Required {
+		found := false
+		for _, alias := range group {
+			found = found || keys[alias]
+		}
+		if !found {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keyvaluepairs"),
+				"Invalid Attribute Configuration",
+				"Expect keyvaluepairs or sensitive_keyvaluepairs to set "+strings.Join(group, " or ")+" for preset "+data.Preset.ValueString(),
+			)
+		}
+	}
+}
+
+// ModifyPlan fails the plan when the server does not support NAMED COLLECTION DDL, and plans the hash of
+// the sensitive pairs so rotated secrets update the collection.
+func (r *NamedCollection) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkFeature(featureNamedCollectionDDL)...)
+
+	var data *NamedCollectionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pairs, diags := data.sensitivePairs(r.secrets.Lookup)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sensitive_keyvaluepairs_hash"), keyValuePairsHash(pairs))...)
+}
+
+// sensitivePairs returns the sensitive pairs with the values of secrets read through lookup.
+func (data *NamedCollectionModel) sensitivePairs(lookup func(path.Path, types.String) (types.String, diag.Diagnostics)) ([]KeyValuePairsModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var pairs []KeyValuePairsModel
+	for i, pair := range data.SensitiveKeyValuePairs {
+		value := pair.Value
+		if !pair.Secret.IsNull() {
+			var d diag.Diagnostics
+			value, d = lookup(path.Root("sensitive_keyvaluepairs").AtListIndex(i).AtName("secret"), pair.Secret)
+			diags.Append(d...)
+		}
+		pairs = append(pairs, KeyValuePairsModel{Key: pair.Key, Value: value, IsNotOverridable: pair.IsNotOverridable})
+	}
+	return pairs, diags
+}
+
+// keyValuePairsHash returns the SHA256 of the pairs sorted by key, unknown while any of them is unknown.
+func keyValuePairsHash(pairs []KeyValuePairsModel) types.String {
+	sorted := append([]KeyValuePairsModel{}, pairs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key.ValueString() < sorted[j].Key.ValueString() })
+
+	hash := sha256.New()
+	for _, pair := range sorted {
+		if pair.Key.IsUnknown() || pair.Value.IsUnknown() {
+			return types.StringUnknown()
+		}
+		hash.Write([]byte(pair.Key.ValueString() + "=" + pair.Value.ValueString() + "\n"))
+	}
+	return types.StringValue(hex.EncodeToString(hash.Sum(nil)))
 }
 
 /*
@@ -160,13 +383,48 @@ CREATE NAMED COLLECTION IF NOT EXISTS "{{.Name.ValueString}}"{{if not .ClusterNa
 {{$size := size .KeyValuePairs}}
 {{$size_sensitive := size .SensitiveKeyValuePairs}}
 {{range $i, $e := .KeyValuePairs}}
-"{{$e.Key.ValueString}}"='{{$e.Value.ValueString}}'{{if or (lt $i $size) (gt $size_sensitive -1)}},{{end}}
+{{template "keyvaluepair" $e}}{{if or (lt $i $size) (gt $size_sensitive -1)}},{{end}}
 {{end}}
 {{range $i, $e := .SensitiveKeyValuePairs}}
-"{{$e.Key.ValueString}}"='{{$e.Value.ValueString}}'{{if lt $i $size_sensitive}},{{end}}
+{{template "keyvaluepair" $e}}{{if lt $i $size_sensitive}},{{end}}
 {{end}}
+` + keyValuePairTemplate
+
+/*
+ALTER NAMED COLLECTION [IF EXISTS] name [ON CLUSTER cluster]
+
+	[ SET key_name1 = 'some value' [[NOT] OVERRIDABLE], key_name2 = 'some value' [[NOT] OVERRIDABLE], ... ]
+	[ DELETE key_name3, key_name4, ... ]
+*/
+const ddlAlterNamedCollectionTemplate = `
+ALTER NAMED COLLECTION "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{$size := size .Set}}{{with .Set}}SET {{range $i, $e := .}}{{template "keyvaluepair" $e}}{{if lt $i $size}}, {{end}}{{end}}{{end}}
+{{$size_delete := size .Delete}}{{with .Delete}}DELETE {{range $i, $e := .}}"{{$e}}"{{if lt $i $size_delete}}, {{end}}{{end}}{{end}}
+` + keyValuePairTemplate
+
+const keyValuePairTemplate = `
+{{define "keyvaluepair"}}"{{.Key.ValueString}}"='{{.Value.ValueString}}'
+{{- if not .IsNotOverridable.IsNull}}{{if .IsNotOverridable.ValueBool}} NOT{{end}} OVERRIDABLE{{end}}{{end}}
+`
+
+// readNamedCollectionQuery asks for secrets, the server still hides them unless display_secrets_in_show_and_select
+// is enabled and the user is granted displaySecretsInShowAndSelect.
+const readNamedCollectionQuery = `
+SELECT collection FROM system.named_collections WHERE name = ?
+SETTINGS format_display_secrets_in_show_and_select = 1
 `
 
+// namedCollectionHiddenValue is reported instead of values the user is not allowed to see.
+const namedCollectionHiddenValue = "[HIDDEN]"
+
+// NamedCollectionStatement renders the DDL with the sensitive pairs resolved, shadowing those of the model.
+type NamedCollectionStatement struct {
+	*NamedCollectionModel
+	SensitiveKeyValuePairs []KeyValuePairsModel
+	Set                    []KeyValuePairsModel
+	Delete                 []string
+}
+
 /*
 DROP NAMED COLLECTION [IF EXISTS] name [on CLUSTER cluster]
 .
@@ -183,7 +441,13 @@ func (r *NamedCollection) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlCreateNamedCollectionTemplate, data)
+	pairs, diags := data.sensitivePairs(r.secrets.Resolve)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateNamedCollectionTemplate, NamedCollectionStatement{NamedCollectionModel: data, SensitiveKeyValuePairs: pairs})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse NamedCollection",
@@ -202,6 +466,7 @@ func (r *NamedCollection) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Name.ValueString())
+	data.SensitiveHash = keyValuePairsHash(pairs)
 
 	tflog.Trace(ctx, "Created a NamedCollection Resource")
 
@@ -219,22 +484,170 @@ func (r *NamedCollection) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	rows, err := r.db.Query(ctx, readNamedCollectionQuery, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse NamedCollection",
+			"Could not read system.named_collections, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		tflog.Trace(ctx, "Named collection not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var collection map[string]string
+	if err := rows.Scan(&collection); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse NamedCollection",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if data.applyCollection(collection) {
+		resp.Diagnostics.AddWarning(
+			"Sensitive Values Hidden by Clickhouse Server",
+			"Changes made outside Terraform to sensitive_keyvaluepairs of "+data.Name.ValueString()+" are not detected, "+
+				"enable display_secrets_in_show_and_select on the server and grant displaySecretsInShowAndSelect to the provider user.",
+		)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// applyCollection updates the model with the keys and values found on the server. Hidden values keep their state
+// value, sensitive values are only compared through SensitiveHash, and keys missing from the state are added to
+// SensitiveKeyValuePairs without their value, as they may hold secrets, so imported collections are complete.
+// It reports whether sensitive values were hidden, in which case SensitiveHash is left unchanged.
+func (data *NamedCollectionModel) applyCollection(collection map[string]string) bool {
+	seen := map[string]bool{}
+
+	var pairs []KeyValuePairsModel
+	for _, pair := range data.KeyValuePairs {
+		value, ok := collection[pair.Key.ValueString()]
+		if !ok {
+			continue
+		}
+		seen[pair.Key.ValueString()] = true
+		if value != namedCollectionHiddenValue {
+			pair.Value = types.StringValue(value)
+		}
+		pairs = append(pairs, pair)
+	}
+
+	var keys []string
+	for key := range collection {
+		if !seen[key] && !data.hasSensitiveKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data.SensitiveKeyValuePairs = append(data.SensitiveKeyValuePairs, SensitiveKeyValuePairsModel{
+			Key:              types.StringValue(key),
+			Value:            types.StringNull(),
+			Secret:           types.StringNull(),
+			IsNotOverridable: types.BoolNull(),
+		})
+	}
+
+	var sensitive []SensitiveKeyValuePairsModel
+	var current []KeyValuePairsModel
+	hidden := false
+	for _, pair := range data.SensitiveKeyValuePairs {
+		value, ok := collection[pair.Key.ValueString()]
+		if !ok {
+			continue
+		}
+		seen[pair.Key.ValueString()] = true
+		hidden = hidden || value == namedCollectionHiddenValue
+		sensitive = append(sensitive, pair)
+		current = append(current, KeyValuePairsModel{Key: pair.Key, Value: types.StringValue(value)})
+	}
+	if !hidden {
+		data.SensitiveHash = keyValuePairsHash(current)
+	}
+
+	data.KeyValuePairs = pairs
+	data.SensitiveKeyValuePairs = sensitive
+	return hidden
+}
+
+// hasSensitiveKey reports whether key is one of the sensitive pairs.
+func (data *NamedCollectionModel) hasSensitiveKey(key string) bool {
+	for _, pair := range data.SensitiveKeyValuePairs {
+		if pair.Key.ValueString() == key {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *NamedCollection) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *NamedCollectionModel
+	var data, state *NamedCollectionModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	pairs, diags := data.sensitivePairs(r.secrets.Resolve)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	alter := NamedCollectionStatement{NamedCollectionModel: data, SensitiveKeyValuePairs: pairs}
+	alter.Set = append(append(alter.Set, data.KeyValuePairs...), pairs...)
+	planned := map[string]bool{}
+	for _, pair := range alter.Set {
+		planned[pair.Key.ValueString()] = true
+	}
+	var stateKeys []types.String
+	for _, pair := range state.KeyValuePairs {
+		stateKeys = append(stateKeys, pair.Key)
+	}
+	for _, pair := range state.SensitiveKeyValuePairs {
+		stateKeys = append(stateKeys, pair.Key)
+	}
+	for _, key := range stateKeys {
+		if !planned[key.ValueString()] {
+			alter.Delete = append(alter.Delete, key.ValueString())
+		}
+	}
+
+	if len(alter.Set) > 0 || len(alter.Delete) > 0 {
+		query, err := common.RenderTemplate(ddlAlterNamedCollectionTemplate, alter)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Clickhouse NamedCollection",
+				"Could not render DDL, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		err = r.db.Exec(ctx, *query)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Clickhouse NamedCollection",
+				"Could not execute DDL, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	data.SensitiveHash = keyValuePairsHash(pairs)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -261,6 +674,8 @@ func (r *NamedCollection) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ImportState accepts either the collection name or the resource ID in the form cluster_name:name.
+// Every key is imported into sensitive_keyvaluepairs without its value, values are set by the next apply.
 func (r *NamedCollection) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp)
 }
@@ -4,9 +4,12 @@
 package provider
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccNamedCollectionResource(t *testing.T) {
@@ -22,11 +25,37 @@ func TestAccNamedCollectionResource(t *testing.T) {
 					resource.TestCheckResourceAttr("clickhouseops_namedcollection.test", "name", "test"),
 				),
 			},
+			// ImportState testing, keys are imported into sensitive_keyvaluepairs without their value
+			{
+				ResourceName:            "clickhouseops_namedcollection.test",
+				ImportState:             true,
+				ImportStateId:           "test",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keyvaluepairs", "sensitive_keyvaluepairs", "sensitive_keyvaluepairs_hash"},
+				ImportStateCheck:        testAccCheckNamedCollectionImportedWithoutValues,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNamedCollectionResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_namedcollection.test", "keyvaluepairs.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_namedcollection.test", "keyvaluepairs.1.value", "5433"),
+				),
+			},
+			// Sensitive value read from the provider secrets
+			{
+				Config: testAccNamedCollectionResourceSecretConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("clickhouseops_namedcollection.test", "sensitive_keyvaluepairs.0.value"),
+					resource.TestCheckResourceAttr("clickhouseops_namedcollection.test", "sensitive_keyvaluepairs.0.secret", "password"),
+				),
+			},
 		},
 	})
 }
 
-const testAccNamedCollectionResourceConfig = `
+const (
+	testAccNamedCollectionResourceConfig = `
 resource "clickhouseops_namedcollection" "test" {
 	name = "test"
 	keyvaluepairs = [{
@@ -45,3 +74,56 @@ resource "clickhouseops_namedcollection" "test" {
 	}]
   }
 `
+	testAccNamedCollectionResourceUpdatedConfig = `
+resource "clickhouseops_namedcollection" "test" {
+	name = "test"
+	keyvaluepairs = [{
+	  key = "host"
+	  value = "localhost"
+	},{
+	  key = "port"
+	  value = "5433"
+	}]
+	sensitive_keyvaluepairs = [{
+	  key = "password"
+	  value = "rotated"
+	}]
+  }
+`
+	testAccNamedCollectionResourceSecretConfig = `
+provider "clickhouseops" {
+	secrets = {
+	  password = "from-secrets-manager"
+	}
+}
+
+resource "clickhouseops_namedcollection" "test" {
+	name = "test"
+	keyvaluepairs = [{
+	  key = "host"
+	  value = "localhost"
+	},{
+	  key = "port"
+	  value = "5433"
+	}]
+	sensitive_keyvaluepairs = [{
+	  key = "password"
+	  secret = "password"
+	}]
+  }
+`
+)
+
+func testAccCheckNamedCollectionImportedWithoutValues(states []*terraform.InstanceState) error {
+	for _, state := range states {
+		if count := state.Attributes["sensitive_keyvaluepairs.#"]; count != "4" {
+			return fmt.Errorf("expected 4 imported sensitive_keyvaluepairs, got %s", count)
+		}
+		for key := range state.Attributes {
+			if strings.HasPrefix(key, "keyvaluepairs.") && key != "keyvaluepairs.#" || strings.HasSuffix(key, ".value") {
+				return fmt.Errorf("expected no imported value, got %s", key)
+			}
+		}
+	}
+	return nil
+}
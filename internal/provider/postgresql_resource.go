@@ -19,9 +19,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &PostgreSQL{}
-	_ resource.ResourceWithConfigure   = &PostgreSQL{}
-	_ resource.ResourceWithImportState = &PostgreSQL{}
+	_ resource.Resource                   = &PostgreSQL{}
+	_ resource.ResourceWithConfigure      = &PostgreSQL{}
+	_ resource.ResourceWithImportState    = &PostgreSQL{}
+	_ resource.ResourceWithValidateConfig = &PostgreSQL{}
+	_ resource.ResourceWithModifyPlan     = &PostgreSQL{}
 )
 
 func NewPostgreSQL() resource.Resource {
@@ -29,7 +31,9 @@ func NewPostgreSQL() resource.Resource {
 }
 
 type PostgreSQL struct {
-	db clickhouse.Conn
+	db      clickhouse.Conn
+	server  *ServerInfo
+	secrets *Secrets
 }
 
 type PostgreSQLModel struct {
@@ -45,6 +49,8 @@ type PostgreSQLModel struct {
 	PostgreSQLTableName    types.String             `tfsdk:"postgresql_table_name"`
 	PostgreSQLUsername     types.String             `tfsdk:"postgresql_username"`
 	PostgreSQLPassword     types.String             `tfsdk:"postgresql_password"`
+	PasswordSecret         types.String             `tfsdk:"postgresql_password_secret"`
+	PasswordSecretHash     types.String             `tfsdk:"postgresql_password_secret_hash"`
 	PostgreSQLSchema       types.String             `tfsdk:"postgresql_schema"`
 }
 
@@ -149,13 +155,24 @@ func (r *PostgreSQL) Schema(ctx context.Context, req resource.SchemaRequest, res
 				},
 			},
 			"postgresql_password": schema.StringAttribute{
-				MarkdownDescription: "Clickhouse PostgreSQL connection password",
+				MarkdownDescription: "Clickhouse PostgreSQL connection password, stored in state. Conflicts with postgresql_password_secret",
 				Optional:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"postgresql_password_secret": schema.StringAttribute{
+				MarkdownDescription: "Name of the provider secret holding the PostgreSQL connection password, which is not stored in state. Conflicts with postgresql_password",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"postgresql_password_secret_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the password read from postgresql_password_secret, a rotated password replaces the table",
+				Computed:            true,
+			},
 			"postgresql_schema": schema.StringAttribute{
 				MarkdownDescription: "Clickhouse PostgreSQL connection schema",
 				Optional:            true,
@@ -184,6 +201,39 @@ func (r *PostgreSQL) Configure(ctx context.Context, req resource.ConfigureReques
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+	r.secrets = providerSecrets(req.ProviderData)
+}
+
+func (r *PostgreSQL) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PostgreSQLModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PostgreSQLPassword.IsNull() && !data.PasswordSecret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("postgresql_password_secret"),
+			"Invalid Attribute Configuration",
+			"Expect only one of postgresql_password or postgresql_password_secret to be set",
+		)
+	}
+}
+
+// ModifyPlan fails the plan when the server was built without PostgreSQL, and replaces the table
+// when the password secret was rotated.
+func (r *PostgreSQL) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkFeature(featurePostgreSQL)...)
+
+	if r.secrets.planSecretHash(ctx, req, resp, "postgresql_password_secret", "postgresql_password_secret_hash") {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("postgresql_password_secret_hash"))
+	}
 }
 
 /*
@@ -242,7 +292,7 @@ func (r *PostgreSQL) Create(ctx context.Context, req resource.CreateRequest, res
 		data.PostgreSQLDatabaseName.IsNull() ||
 		data.PostgreSQLTableName.IsNull() ||
 		data.PostgreSQLUsername.IsNull() ||
-		data.PostgreSQLPassword.IsNull()) {
+		(data.PostgreSQLPassword.IsNull() && data.PasswordSecret.IsNull())) {
 		resp.Diagnostics.AddError(
 			"Missing Attribute Configuration",
 			"Expect a Clickhouse named collection or the complete set of PostgreSQL connection parameters",
@@ -250,7 +300,20 @@ func (r *PostgreSQL) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlCreatePostgreSQLTemplate, data)
+	password, diags := r.secrets.Resolve(path.Root("postgresql_password_secret"), data.PasswordSecret)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.PasswordSecretHash = secretHash(password)
+
+	// The secret is only rendered into the DDL, the model written to state keeps the name.
+	statement := *data
+	if !password.IsNull() {
+		statement.PostgreSQLPassword = password
+	}
+
+	query, err := common.RenderTemplate(ddlCreatePostgreSQLTemplate, statement)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse PostgreSQL",
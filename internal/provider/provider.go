@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -40,6 +42,7 @@ type ClickhouseProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	Secure   types.Bool   `tfsdk:"secure"`
+	Secrets  types.Map    `tfsdk:"secrets"`
 }
 
 func (p *ClickhouseProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -71,6 +74,13 @@ func (p *ClickhouseProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				MarkdownDescription: "Clickhouse secure connection using SSL/TLS",
 				Optional:            true,
 			},
+			"secrets": schema.MapAttribute{
+				MarkdownDescription: "Secrets referenced by name from the `*_secret` attributes of resources, ie. values of ephemeral resources " +
+					"reading a secrets manager. Secrets are only sent to Clickhouse, state keeps their SHA256 to detect rotations",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
 		},
 	}
 }
@@ -135,8 +145,27 @@ func (p *ClickhouseProvider) Configure(ctx context.Context, req provider.Configu
 		)
 		return
 	}
-	resp.DataSourceData = db
-	resp.ResourceData = db
+
+	conn := &ServerConn{Conn: db, Secrets: &Secrets{}}
+	if !data.Secrets.IsUnknown() {
+		conn.Secrets.values = map[string]types.String{}
+	}
+	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
+		resp.Diagnostics.Append(data.Secrets.ElementsAs(ctx, &conn.Secrets.values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	conn.Info, err = detectServer(ctx, db)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Detect Clickhouse Server Version",
+			"Version and feature checks are skipped, "+
+				"Clickhouse Client Error: "+err.Error(),
+		)
+	}
+	resp.DataSourceData = conn
+	resp.ResourceData = conn
 }
 
 func (p *ClickhouseProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -157,12 +186,28 @@ func (p *ClickhouseProvider) Resources(ctx context.Context) []func() resource.Re
 		NewRevokeSelect,
 		NewGrantAll,
 		NewDistributed,
+		NewDictionary,
+		NewFunction,
+		NewSettingsProfile,
+		NewQuota,
+		NewRowPolicy,
+		NewGrant,
+		NewRoleGrants,
 	}
 }
 
 func (p *ClickhouseProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewS3DescribeDataSource,
+		NewUsersDataSource,
+		NewRolesDataSource,
+		NewGrantsDataSource,
+		NewRoleGrantsDataSource,
+		NewEffectivePrivilegesDataSource,
+		NewTableSchemaDataSource,
+		NewDescribeDataSource,
+		NewClusterDataSource,
+		NewServerInfoDataSource,
 	}
 }
 
@@ -180,3 +225,48 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// importStateByName sets id, name and cluster_name from an import ID in the form name or cluster_name:name.
+func importStateByName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterName, name, found := strings.Cut(req.ID, ":")
+	if !found {
+		clusterName, name = "", req.ID
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterName+":"+name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	if clusterName != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
+
+func stringValues(values []string) []types.String {
+	var result []types.String
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
+
+// queryFilter restricts a system table query to the rows matching Condition, unless Value is null.
+type queryFilter struct {
+	Condition string
+	Value     types.String
+}
+
+// filterQuery appends the conditions of every set filter to query and returns the arguments to bind.
+func filterQuery(query string, filters ...queryFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	for _, filter := range filters {
+		if filter.Value.IsNull() || filter.Value.IsUnknown() {
+			continue
+		}
+		conditions = append(conditions, filter.Condition)
+		args = append(args, filter.Value.ValueString())
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query, args
+}
@@ -0,0 +1,545 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+var (
+	_ resource.Resource                   = &Quota{}
+	_ resource.ResourceWithConfigure      = &Quota{}
+	_ resource.ResourceWithImportState    = &Quota{}
+	_ resource.ResourceWithValidateConfig = &Quota{}
+)
+
+func NewQuota() resource.Resource {
+	return &Quota{}
+}
+
+type Quota struct {
+	db clickhouse.Conn
+}
+
+type QuotaModel struct {
+	ID          types.String         `tfsdk:"id"`
+	Name        types.String         `tfsdk:"name"`
+	ClusterName types.String         `tfsdk:"cluster_name"`
+	KeyedBy     types.String         `tfsdk:"keyed_by"`
+	Intervals   []QuotaIntervalModel `tfsdk:"intervals"`
+	To          []types.String       `tfsdk:"to"`
+}
+
+type QuotaIntervalModel struct {
+	Duration      types.Int64   `tfsdk:"duration"`
+	Unit          types.String  `tfsdk:"unit"`
+	Randomized    types.Bool    `tfsdk:"randomized"`
+	Queries       types.Int64   `tfsdk:"queries"`
+	QuerySelects  types.Int64   `tfsdk:"query_selects"`
+	QueryInserts  types.Int64   `tfsdk:"query_inserts"`
+	Errors        types.Int64   `tfsdk:"errors"`
+	ResultRows    types.Int64   `tfsdk:"result_rows"`
+	ResultBytes   types.Int64   `tfsdk:"result_bytes"`
+	ReadRows      types.Int64   `tfsdk:"read_rows"`
+	ReadBytes     types.Int64   `tfsdk:"read_bytes"`
+	ExecutionTime types.Float64 `tfsdk:"execution_time"`
+}
+
+// quotaIntervalUnits maps interval units to seconds as ClickHouse does when storing the quota duration.
+var quotaIntervalUnits = map[string]int64{
+	"SECOND":  1,
+	"MINUTE":  60,
+	"HOUR":    3600,
+	"DAY":     86400,
+	"WEEK":    604800,
+	"MONTH":   2629746,
+	"QUARTER": 7889238,
+	"YEAR":    31556952,
+}
+
+// Seconds returns the interval duration in seconds, unit defaults to second.
+func (m QuotaIntervalModel) Seconds() int64 {
+	if m.Unit.IsNull() {
+		return m.Duration.ValueInt64()
+	}
+	return m.Duration.ValueInt64() * quotaIntervalUnits[strings.ToUpper(m.Unit.ValueString())]
+}
+
+// MaxLimits returns the MAX assignments of the interval. When altering, limits which are not
+// configured are reset to 0 (unlimited) because ALTER QUOTA keeps the limits it is not given.
+func (m QuotaIntervalModel) MaxLimits(alter bool) []string {
+	var limits []string
+	for _, limit := range []struct {
+		name  string
+		value types.Int64
+	}{
+		{"queries", m.Queries},
+		{"query_selects", m.QuerySelects},
+		{"query_inserts", m.QueryInserts},
+		{"errors", m.Errors},
+		{"result_rows", m.ResultRows},
+		{"result_bytes", m.ResultBytes},
+		{"read_rows", m.ReadRows},
+		{"read_bytes", m.ReadBytes},
+	} {
+		if !limit.value.IsNull() {
+			limits = append(limits, fmt.Sprintf("%s = %d", limit.name, limit.value.ValueInt64()))
+		} else if alter {
+			limits = append(limits, limit.name+" = 0")
+		}
+	}
+	if !m.ExecutionTime.IsNull() {
+		limits = append(limits, "execution_time = "+strconv.FormatFloat(m.ExecutionTime.ValueFloat64(), 'f', -1, 64))
+	} else if alter {
+		limits = append(limits, "execution_time = 0")
+	}
+	return limits
+}
+
+func quotaLimitAttribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: description,
+		Optional:            true,
+	}
+}
+
+func (r *Quota) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quota"
+}
+
+func (r *Quota) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse quota",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse quota name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse cluster name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keyed_by": schema.StringAttribute{
+				MarkdownDescription: "Key the quota is tracked by, one of `user_name`, `ip_address`, `client_key`, `client_key,user_name` or `client_key,ip_address`. Not keyed when omitted",
+				Optional:            true,
+			},
+			"intervals": schema.ListNestedAttribute{
+				MarkdownDescription: "Quota intervals with their limits, an interval without limits only tracks consumption",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"duration": schema.Int64Attribute{
+							MarkdownDescription: "Interval length in `unit`",
+							Required:            true,
+						},
+						"unit": schema.StringAttribute{
+							MarkdownDescription: "Interval unit, one of second, minute, hour, day, week, month, quarter or year. Defaults to second",
+							Optional:            true,
+						},
+						"randomized": schema.BoolAttribute{
+							MarkdownDescription: "If true the interval start is randomized",
+							Optional:            true,
+						},
+						"queries":       quotaLimitAttribute("Maximum number of queries"),
+						"query_selects": quotaLimitAttribute("Maximum number of select queries"),
+						"query_inserts": quotaLimitAttribute("Maximum number of insert queries"),
+						"errors":        quotaLimitAttribute("Maximum number of queries that threw an exception"),
+						"result_rows":   quotaLimitAttribute("Maximum number of rows given as a result"),
+						"result_bytes":  quotaLimitAttribute("Maximum number of bytes given as a result"),
+						"read_rows":     quotaLimitAttribute("Maximum number of rows read from tables"),
+						"read_bytes":    quotaLimitAttribute("Maximum number of bytes read from tables"),
+						"execution_time": schema.Float64Attribute{
+							MarkdownDescription: "Maximum query execution time in seconds",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"to": schema.SetAttribute{
+				MarkdownDescription: "Users or roles the quota is assigned to",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *Quota) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *Quota) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data QuotaModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.KeyedBy.IsNull() && !data.KeyedBy.IsUnknown() {
+		switch strings.ReplaceAll(strings.ToLower(data.KeyedBy.ValueString()), " ", "") {
+		case "user_name", "ip_address", "client_key", "client_key,user_name", "client_key,ip_address":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keyed_by"),
+				"Invalid Attribute Configuration",
+				"Expect keyed_by to be one of user_name, ip_address, client_key, client_key,user_name or client_key,ip_address, got: "+data.KeyedBy.ValueString(),
+			)
+		}
+	}
+
+	seconds := map[int64]bool{}
+	for i, interval := range data.Intervals {
+		if interval.Unit.IsUnknown() || interval.Duration.IsUnknown() {
+			continue
+		}
+		if _, ok := quotaIntervalUnits[strings.ToUpper(interval.Unit.ValueString())]; !interval.Unit.IsNull() && !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("intervals").AtListIndex(i).AtName("unit"),
+				"Invalid Attribute Configuration",
+				"Expect unit to be one of second, minute, hour, day, week, month, quarter or year, got: "+interval.Unit.ValueString(),
+			)
+			continue
+		}
+		if seconds[interval.Seconds()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("intervals").AtListIndex(i),
+				"Invalid Attribute Configuration",
+				"Expect quota intervals to have different durations",
+			)
+		}
+		seconds[interval.Seconds()] = true
+	}
+}
+
+/*
+	Clickhouse Quota Syntax for reference
+
+CREATE QUOTA [IF NOT EXISTS | OR REPLACE] name [ON CLUSTER cluster_name]
+
+	[IN access_storage_type]
+	[KEYED BY {user_name | ip_address | client_key | client_key,user_name | client_key,ip_address} | NOT KEYED]
+	[FOR [RANDOMIZED] INTERVAL number {second | minute | hour | day | week | month | quarter | year}
+	    {MAX { {queries | query_selects | query_inserts | errors | result_rows | result_bytes | read_rows | read_bytes | execution_time} = number } [,...] |
+	     NO LIMITS | TRACKING ONLY} [,...]]
+	[TO {role [,...] | ALL | ALL EXCEPT role [,...]}]
+*/
+const ddlCreateQuotaTemplate = `
+{{if .Alter}}ALTER{{else}}CREATE{{end}} QUOTA "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{if not .KeyedBy.IsNull}}KEYED BY {{.KeyedBy.ValueString}}{{else}}NOT KEYED{{end}}
+{{$size := size .Intervals}}{{$size_removed := size .Removed}}
+{{range $i, $e := .Intervals}}
+FOR {{if $e.Randomized.ValueBool}}RANDOMIZED {{end}}INTERVAL {{$e.Duration.ValueInt64}} {{if $e.Unit.IsNull}}second{{else}}{{$e.Unit.ValueString}}{{end}}
+{{$limits := $e.MaxLimits $.Alter}}{{$size_limits := size $limits}}{{if $limits}}MAX {{range $j, $l := $limits}}{{$l}}{{if lt $j $size_limits}}, {{end}}{{end}}{{else}}TRACKING ONLY{{end}}{{if or (lt $i $size) (gt $size_removed -1)}},{{end}}
+{{end}}
+{{range $i, $e := .Removed}}
+FOR INTERVAL {{$e}} second NO LIMITS{{if lt $i $size_removed}},{{end}}
+{{end}}
+{{$size_to := size .To}}
+{{if .To}}TO {{range $i, $e := .To}}'{{$e.ValueString}}'{{if lt $i $size_to}},{{end}}{{end}}{{else if .Alter}}TO NONE{{end}}
+`
+
+/*
+DROP QUOTA [IF EXISTS] name [,...] [ON CLUSTER cluster_name] [FROM access_storage_type]
+.
+*/
+const ddlDropQuotaTemplate = `
+DROP QUOTA IF EXISTS "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+`
+
+const readQuotaQuery = `SELECT arrayMap(x -> toString(x), keys), apply_to_list FROM system.quotas WHERE name = ?`
+
+const readQuotaLimitsQuery = `
+SELECT duration, is_randomized_interval, max_queries, max_query_selects, max_query_inserts, max_errors,
+	max_result_rows, max_result_bytes, max_read_rows, max_read_bytes, max_execution_time
+FROM system.quota_limits
+WHERE quota_name = ?
+ORDER BY duration
+`
+
+type QuotaStatement struct {
+	*QuotaModel
+	Alter bool
+	// Removed contains the durations in seconds of the intervals to drop.
+	Removed []int64
+}
+
+func (r *Quota) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *QuotaModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateQuotaTemplate, QuotaStatement{QuotaModel: data})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Quota",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Quota",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Name.ValueString())
+
+	tflog.Trace(ctx, "Created a Quota Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Quota) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *QuotaModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := r.db.Query(ctx, readQuotaQuery, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Quota",
+			"Could not read system.quotas, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		tflog.Trace(ctx, "Quota not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var keys, applyTo []string
+	if err := rows.Scan(&keys, &applyTo); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Quota",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	keyedBy := strings.Join(keys, ",")
+	switch {
+	case keyedBy == "":
+		data.KeyedBy = types.StringNull()
+	case strings.ReplaceAll(strings.ToLower(data.KeyedBy.ValueString()), " ", "") != keyedBy:
+		data.KeyedBy = types.StringValue(keyedBy)
+	}
+	data.To = stringValues(applyTo)
+
+	intervals, err := r.readIntervals(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Quota",
+			"Could not read system.quota_limits, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.Intervals = intervals
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// readIntervals reads the quota intervals keeping the configured duration unit when it matches the server.
+// Configured intervals keep their order, the others follow by duration.
+func (r *Quota) readIntervals(ctx context.Context, data *QuotaModel) ([]QuotaIntervalModel, error) {
+	rows, err := r.db.Query(ctx, readQuotaLimitsQuery, data.Name.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	configured := make(map[int64]QuotaIntervalModel, len(data.Intervals))
+	position := make(map[int64]int, len(data.Intervals))
+	for i, interval := range data.Intervals {
+		configured[interval.Seconds()] = interval
+		position[interval.Seconds()] = i
+	}
+
+	var intervals []QuotaIntervalModel
+	for rows.Next() {
+		var duration uint32
+		var randomized uint8
+		var queries, querySelects, queryInserts, errors, resultRows, resultBytes, readRows, readBytes *uint64
+		var executionTime *float64
+		if err := rows.Scan(&duration, &randomized, &queries, &querySelects, &queryInserts, &errors,
+			&resultRows, &resultBytes, &readRows, &readBytes, &executionTime); err != nil {
+			return nil, err
+		}
+
+		interval := QuotaIntervalModel{
+			Duration:      types.Int64Value(int64(duration)),
+			Unit:          types.StringValue("second"),
+			Randomized:    types.BoolValue(randomized != 0),
+			Queries:       quotaLimitValue(queries),
+			QuerySelects:  quotaLimitValue(querySelects),
+			QueryInserts:  quotaLimitValue(queryInserts),
+			Errors:        quotaLimitValue(errors),
+			ResultRows:    quotaLimitValue(resultRows),
+			ResultBytes:   quotaLimitValue(resultBytes),
+			ReadRows:      quotaLimitValue(readRows),
+			ReadBytes:     quotaLimitValue(readBytes),
+			ExecutionTime: types.Float64PointerValue(executionTime),
+		}
+		if previous, ok := configured[int64(duration)]; ok {
+			interval.Duration = previous.Duration
+			interval.Unit = previous.Unit
+			if previous.Randomized.IsNull() && randomized == 0 {
+				interval.Randomized = previous.Randomized
+			}
+		}
+		intervals = append(intervals, interval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Rows are ordered by duration, unconfigured intervals keep that order after the configured ones.
+	order := func(interval QuotaIntervalModel) int {
+		if i, ok := position[interval.Seconds()]; ok {
+			return i
+		}
+		return len(position)
+	}
+	sort.SliceStable(intervals, func(i, j int) bool { return order(intervals[i]) < order(intervals[j]) })
+	return intervals, nil
+}
+
+func quotaLimitValue(value *uint64) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
+
+func (r *Quota) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *QuotaModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := map[int64]bool{}
+	for _, interval := range data.Intervals {
+		planned[interval.Seconds()] = true
+	}
+	statement := QuotaStatement{QuotaModel: data, Alter: true}
+	for _, interval := range state.Intervals {
+		if !planned[interval.Seconds()] {
+			statement.Removed = append(statement.Removed, interval.Seconds())
+		}
+	}
+
+	query, err := common.RenderTemplate(ddlCreateQuotaTemplate, statement)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Quota",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Quota",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Quota) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *QuotaModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlDropQuotaTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts either the quota name or the resource ID in the form cluster_name:name.
+func (r *Quota) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByName(ctx, req, resp)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccQuotaResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccQuotaResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_quota.analysts", "intervals.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_quota.analysts", "intervals.0.unit", "day"),
+					resource.TestCheckResourceAttr("clickhouseops_quota.analysts", "intervals.1.queries", "1000"),
+					resource.TestCheckResourceAttr("clickhouseops_quota.analysts", "to.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "clickhouseops_quota.analysts",
+				ImportState:             true,
+				ImportStateId:           "analysts",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"intervals"},
+			},
+			// Update in place
+			{
+				Config: testAccQuotaResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_quota.analysts", "intervals.#", "1"),
+					resource.TestCheckResourceAttr("clickhouseops_quota.analysts", "intervals.0.queries", "2000"),
+					resource.TestCheckNoResourceAttr("clickhouseops_quota.analysts", "intervals.0.errors"),
+					resource.TestCheckNoResourceAttr("clickhouseops_quota.analysts", "keyed_by"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccQuotaResourceConfig = `
+resource "clickhouseops_simplerole" "analyst" {
+	name = "analyst"
+}
+
+resource "clickhouseops_quota" "analysts" {
+	name = "analysts"
+	keyed_by = "user_name"
+	intervals = [{
+		duration = 1
+		unit = "day"
+		read_rows = 1000000000
+	}, {
+		duration = 1
+		unit = "hour"
+		queries = 1000
+		errors = 100
+	}]
+	to = [clickhouseops_simplerole.analyst.name]
+}
+`
+	testAccQuotaResourceUpdatedConfig = `
+resource "clickhouseops_simplerole" "analyst" {
+	name = "analyst"
+}
+
+resource "clickhouseops_quota" "analysts" {
+	name = "analysts"
+	intervals = [{
+		duration = 1
+		unit = "hour"
+		queries = 2000
+	}]
+	to = [clickhouseops_simplerole.analyst.name]
+}
+`
+)
@@ -24,6 +24,7 @@ var (
 	_ resource.Resource                = &ReplacingMergeTree{}
 	_ resource.ResourceWithConfigure   = &ReplacingMergeTree{}
 	_ resource.ResourceWithImportState = &ReplacingMergeTree{}
+	_ resource.ResourceWithModifyPlan  = &ReplacingMergeTree{}
 )
 
 func NewReplacingMergeTree() resource.Resource {
@@ -31,7 +32,8 @@ func NewReplacingMergeTree() resource.Resource {
 }
 
 type ReplacingMergeTree struct {
-	db clickhouse.Conn
+	db     clickhouse.Conn
+	server *ServerInfo
 }
 
 type ReplacingMergeTreeModel struct {
@@ -201,6 +203,16 @@ func (r *ReplacingMergeTree) Configure(ctx context.Context, req resource.Configu
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+}
+
+// ModifyPlan fails the plan when a setting is not known by the server.
+func (r *ReplacingMergeTree) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkSettings(ctx, req.Plan, true)...)
 }
 
 /* Clickhouse ReplacingMergeTree Syntax for reference
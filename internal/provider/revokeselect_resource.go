@@ -6,10 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -147,11 +145,7 @@ func (r *RevokeSelect) Create(ctx context.Context, req resource.CreateRequest, r
 		)
 		return
 	}
-	var columns []string
-	for _, v := range data.ColumnsName {
-		columns = append(columns, v.ValueString())
-	}
-	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + strings.Join(columns, ":") + ":" + data.Assignee.ValueString())
+	data.ID = types.StringValue(selectGrantID(data.ClusterName, data.DatabaseName, data.TableName, data.Assignee))
 
 	tflog.Trace(ctx, "Created a RevokeSelect Resource")
 
@@ -169,6 +163,10 @@ func (r *RevokeSelect) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// States created before columns were updated in place hold IDs in the form cluster:columns:assignee,
+	// migrating here lets the plan carry the current ID into Update.
+	data.ID = types.StringValue(selectGrantID(data.ClusterName, data.DatabaseName, data.TableName, data.Assignee))
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -176,15 +174,52 @@ func (r *RevokeSelect) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *RevokeSelect) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *RevokeSelectModel
+	var data, state *RevokeSelectModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Removed columns are handled as a destroy, added columns as a create.
+	removed, destroy, added, create := selectColumnsDelta(state.ColumnsName, data.ColumnsName)
+	statements := []struct {
+		template string
+		columns  []types.String
+		run      bool
+	}{
+		{ddlDestroyRevokeSelectTemplate, removed, destroy},
+		{ddlCreateRevokeSelectTemplate, added, create},
+	}
+	for _, statement := range statements {
+		if !statement.run {
+			continue
+		}
+
+		delta := *data
+		delta.ColumnsName = statement.columns
+		query, err := common.RenderTemplate(statement.template, delta)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				"Could not render DDL, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		err = r.db.Exec(ctx, *query)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Permissions",
+				"Could not execute DDL, unexpected error: "+*query+err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -216,5 +251,5 @@ func (r *RevokeSelect) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *RevokeSelect) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importSelectGrant(ctx, req, resp)
 }
@@ -20,11 +20,52 @@ func TestAccRevokeSelectResource(t *testing.T) {
 					resource.TestCheckResourceAttr("clickhouseops_revokeselect.new_revoke", "assignee", "user2"),
 				),
 			},
+			// Update columns in place
+			{
+				Config: testAccRevokeSelectUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_revokeselect.new_revoke", "columns_name.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_revokeselect.new_revoke", "id", ":system:tables:user2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "clickhouseops_revokeselect.new_revoke",
+				ImportState:             true,
+				ImportStateId:           "system:tables:user2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"columns_name"},
+			},
+		},
+	})
+}
+
+func TestAccRevokeSelectResourceLegacyID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			// Create with the ID format used before columns were updated in place
+			{
+				ProtoV6ProviderFactories: testAccLegacySelectIDProviderFactories,
+				Config:                   testAccRevokeSelectConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_revokeselect.new_revoke", "id", ":database:name:user2"),
+				),
+			},
+			// Update columns in place from the old ID
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config:                   testAccRevokeSelectUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_revokeselect.new_revoke", "columns_name.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_revokeselect.new_revoke", "id", ":system:tables:user2"),
+				),
+			},
 		},
 	})
 }
 
-const testAccRevokeSelectConfig = `
+const (
+	testAccRevokeSelectConfig = `
 resource "clickhouseops_simpleuser" "user2" {
 	name = "user2"
 	sha256_password = sha256("password2")
@@ -37,3 +78,17 @@ resource "clickhouseops_revokeselect" "new_revoke" {
 	assignee = clickhouseops_simpleuser.user2.name
 }
 `
+	testAccRevokeSelectUpdatedConfig = `
+resource "clickhouseops_simpleuser" "user2" {
+	name = "user2"
+	sha256_password = sha256("password2")
+}
+
+resource "clickhouseops_revokeselect" "new_revoke" {
+	database_name = "system"
+	table_name = "tables"
+	columns_name = ["database", "engine"]
+	assignee = clickhouseops_simpleuser.user2.name
+}
+`
+)
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoleGrantsDataSource{}
+
+func NewRoleGrantsDataSource() datasource.DataSource {
+	return &RoleGrantsDataSource{}
+}
+
+// RoleGrantsDataSource lists the roles granted to users and roles found in system.role_grants.
+type RoleGrantsDataSource struct {
+	db clickhouse.Conn
+}
+
+type RoleGrantsDataSourceModel struct {
+	Id         types.String         `tfsdk:"id"`
+	Assignee   types.String         `tfsdk:"assignee"`
+	RoleName   types.String         `tfsdk:"role_name"`
+	RoleGrants []RoleGrantDataModel `tfsdk:"role_grants"`
+}
+
+type RoleGrantDataModel struct {
+	Assignee        types.String `tfsdk:"assignee"`
+	RoleName        types.String `tfsdk:"role_name"`
+	IsDefault       types.Bool   `tfsdk:"is_default"`
+	WithAdminOption types.Bool   `tfsdk:"with_admin_option"`
+}
+
+func (d *RoleGrantsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_grants"
+}
+
+func (d *RoleGrantsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to look up roles granted to users and roles from system.role_grants",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"assignee": schema.StringAttribute{
+				MarkdownDescription: "Only return roles granted to this user or role",
+				Optional:            true,
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Only return grants of this role",
+				Optional:            true,
+			},
+			"role_grants": schema.ListNestedAttribute{
+				MarkdownDescription: "Role grants ordered by assignee",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"assignee": schema.StringAttribute{
+							MarkdownDescription: "User or role the role is granted to",
+							Computed:            true,
+						},
+						"role_name": schema.StringAttribute{
+							MarkdownDescription: "Granted role",
+							Computed:            true,
+						},
+						"is_default": schema.BoolAttribute{
+							MarkdownDescription: "Whether the granted role is a default role of the assignee",
+							Computed:            true,
+						},
+						"with_admin_option": schema.BoolAttribute{
+							MarkdownDescription: "Whether the role is granted WITH ADMIN OPTION",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoleGrantsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readRoleGrantsDataQuery = `
+SELECT assignee, granted_role_name, toBool(granted_role_is_default), toBool(with_admin_option)
+FROM (
+	SELECT ifNull(user_name, role_name) AS assignee, granted_role_name, granted_role_is_default, with_admin_option
+	FROM system.role_grants
+)`
+
+func (d *RoleGrantsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoleGrantsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, args := filterQuery(readRoleGrantsDataQuery,
+		queryFilter{Condition: "assignee = ?", Value: data.Assignee},
+		queryFilter{Condition: "granted_role_name = ?", Value: data.RoleName},
+	)
+	rows, err := d.db.Query(ctx, query+" ORDER BY assignee, granted_role_name", args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Role Grants",
+			"Could not read system.role_grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	data.RoleGrants = nil
+	for rows.Next() {
+		var assignee, roleName string
+		var isDefault, adminOption bool
+		if err := rows.Scan(&assignee, &roleName, &isDefault, &adminOption); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Role Grants",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		data.RoleGrants = append(data.RoleGrants, RoleGrantDataModel{
+			Assignee:        types.StringValue(assignee),
+			RoleName:        types.StringValue(roleName),
+			IsDefault:       types.BoolValue(isDefault),
+			WithAdminOption: types.BoolValue(adminOption),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Role Grants",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(data.Assignee.ValueString() + ":" + data.RoleName.ValueString())
+
+	tflog.Trace(ctx, "read role grants data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
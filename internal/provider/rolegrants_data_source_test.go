@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoleGrantsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleGrantsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_role_grants.lookup", "role_grants.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_role_grants.lookup", "role_grants.0.assignee", "lookup_role_grants_user"),
+					resource.TestCheckResourceAttr("data.clickhouseops_role_grants.lookup", "role_grants.0.role_name", "lookup_role_grants_role"),
+					resource.TestCheckResourceAttr("data.clickhouseops_role_grants.lookup", "role_grants.0.with_admin_option", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRoleGrantsDataSourceConfig = `
+resource "clickhouseops_simplerole" "lookup_role_grants_role" {
+  name = "lookup_role_grants_role"
+}
+
+resource "clickhouseops_simpleuser" "lookup_role_grants_user" {
+  name = "lookup_role_grants_user"
+  sha256_password = sha256("dummy_password")
+}
+
+resource "clickhouseops_grantrole" "lookup" {
+  role_name = clickhouseops_simplerole.lookup_role_grants_role.name
+  user_name = clickhouseops_simpleuser.lookup_role_grants_user.name
+}
+
+data "clickhouseops_role_grants" "lookup" {
+  assignee = clickhouseops_simpleuser.lookup_role_grants_user.name
+  depends_on = [clickhouseops_grantrole.lookup]
+}
+`
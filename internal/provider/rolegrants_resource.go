@@ -0,0 +1,516 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = &RoleGrants{}
+	_ resource.ResourceWithConfigure      = &RoleGrants{}
+	_ resource.ResourceWithImportState    = &RoleGrants{}
+	_ resource.ResourceWithValidateConfig = &RoleGrants{}
+)
+
+func NewRoleGrants() resource.Resource {
+	return &RoleGrants{}
+}
+
+type RoleGrants struct {
+	db clickhouse.Conn
+}
+
+type RoleGrantsModel struct {
+	ID          types.String               `tfsdk:"id"`
+	ClusterName types.String               `tfsdk:"cluster_name"`
+	Assignee    types.String               `tfsdk:"assignee"`
+	Grants      []RoleGrantsPrivilegeModel `tfsdk:"grants"`
+	Roles       []types.String             `tfsdk:"roles"`
+}
+
+type RoleGrantsPrivilegeModel struct {
+	Privilege       types.String   `tfsdk:"privilege"`
+	Columns         []types.String `tfsdk:"columns"`
+	DatabaseName    types.String   `tfsdk:"database_name"`
+	TableName       types.String   `tfsdk:"table_name"`
+	NamedCollection types.String   `tfsdk:"named_collection"`
+	WithGrantOption types.Bool     `tfsdk:"with_grant_option"`
+	Revoked         types.Bool     `tfsdk:"revoked"`
+}
+
+// target returns the grant model of the privilege target, used to render GRANT and REVOKE statements.
+func (m RoleGrantsPrivilegeModel) target(data *RoleGrantsModel) *GrantModel {
+	return &GrantModel{
+		ClusterName:     data.ClusterName,
+		DatabaseName:    m.DatabaseName,
+		TableName:       m.TableName,
+		NamedCollection: m.NamedCollection,
+		WithGrantOption: m.WithGrantOption,
+		Assignee:        data.Assignee,
+	}
+}
+
+// key identifies the privilege by target, name, grant option and partial revoke, columns excluded.
+func (m RoleGrantsPrivilegeModel) key() string {
+	return fmt.Sprintf("%s:%s:%t:%t", grantTargetID(m.target(&RoleGrantsModel{})), strings.ToUpper(m.Privilege.ValueString()), m.WithGrantOption.ValueBool(), m.Revoked.ValueBool())
+}
+
+func (r *RoleGrants) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_grants"
+}
+
+func (r *RoleGrants) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse complete privilege set of a user or a role (Assignee), privileges and roles which are not declared are revoked. " +
+			"Roles granted to the assignee are managed by this resource, do not combine it with clickhouseops_grantrole for the same assignee",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse cluster name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"assignee": schema.StringAttribute{
+				MarkdownDescription: "User or Role owning the privileges",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grants": schema.SetNestedAttribute{
+				MarkdownDescription: "Complete list of privileges of the assignee, no privileges when omitted",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege name as shown in system.privileges, e.g. INSERT, CREATE TABLE, dictGet, SYSTEM RELOAD or S3",
+							Required:            true,
+						},
+						"columns": schema.ListAttribute{
+							MarkdownDescription: "Columns the privilege is restricted to, all columns when omitted",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"database_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the database the privilege is granted on, all databases (`*.*`) when omitted",
+							Optional:            true,
+						},
+						"table_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the table the privilege is granted on, all tables of the database when omitted",
+							Optional:            true,
+						},
+						"named_collection": schema.StringAttribute{
+							MarkdownDescription: "Name of the named collection the privilege is granted on, conflicts with database_name",
+							Optional:            true,
+						},
+						"with_grant_option": schema.BoolAttribute{
+							MarkdownDescription: "If true the assignee can grant the privilege to others, with revoked only the grant option is revoked",
+							Optional:            true,
+						},
+						"revoked": schema.BoolAttribute{
+							MarkdownDescription: "If true the privilege is partially revoked from a broader privilege, e.g. SELECT revoked on a table while granted on its database",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"roles": schema.SetAttribute{
+				MarkdownDescription: "Complete list of roles granted to the assignee, no roles when omitted",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *RoleGrants) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *RoleGrants) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleGrantsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, grant := range data.Grants {
+		switch {
+		case !grant.NamedCollection.IsNull() && !grant.DatabaseName.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grants"),
+				"Invalid Attribute Configuration",
+				"Expect only one of named_collection or database_name to be set for privilege "+grant.Privilege.ValueString(),
+			)
+		case !grant.TableName.IsNull() && grant.DatabaseName.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grants"),
+				"Invalid Attribute Configuration",
+				"Expect database_name to be set when table_name is set for privilege "+grant.Privilege.ValueString(),
+			)
+		case len(grant.Columns) > 0 && grant.TableName.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grants"),
+				"Invalid Attribute Configuration",
+				"Expect table_name to be set when privilege "+grant.Privilege.ValueString()+" is restricted to columns",
+			)
+		}
+	}
+}
+
+const readRoleGrantsAssigneeQuery = `
+SELECT name FROM system.roles WHERE name = ?
+UNION ALL
+SELECT name FROM system.users WHERE name = ?
+`
+
+const readRoleGrantsQuery = `
+SELECT toString(access_type), database, table, column, grant_option, is_partial_revoke
+FROM system.grants
+WHERE user_name = ? OR role_name = ?
+`
+
+const readRoleGrantsRolesQuery = `
+SELECT granted_role_name
+FROM system.role_grants
+WHERE user_name = ? OR role_name = ?
+ORDER BY granted_role_name
+`
+
+// readGrants returns every privilege of the assignee, found is false when the assignee does not exist.
+// Configured privileges keep their name and column order.
+func (r *RoleGrants) readGrants(ctx context.Context, data *RoleGrantsModel) (grants []RoleGrantsPrivilegeModel, found bool, err error) {
+	assignees, err := r.db.Query(ctx, readRoleGrantsAssigneeQuery, data.Assignee.ValueString(), data.Assignee.ValueString())
+	if err != nil {
+		return nil, false, err
+	}
+	found = assignees.Next()
+	assignees.Close()
+	if !found {
+		return nil, false, nil
+	}
+
+	rows, err := r.db.Query(ctx, readRoleGrantsQuery, data.Assignee.ValueString(), data.Assignee.ValueString())
+	if err != nil {
+		return nil, true, err
+	}
+	defer rows.Close()
+
+	configured := map[string]RoleGrantsPrivilegeModel{}
+	for _, grant := range data.Grants {
+		configured[grant.key()] = grant
+	}
+
+	index := map[string]int{}
+	for rows.Next() {
+		var accessType string
+		var database, table, column *string
+		var grantOption, isPartialRevoke uint8
+		if err := rows.Scan(&accessType, &database, &table, &column, &grantOption, &isPartialRevoke); err != nil {
+			return nil, true, err
+		}
+
+		grant := RoleGrantsPrivilegeModel{
+			Privilege:       types.StringValue(accessType),
+			DatabaseName:    types.StringPointerValue(database),
+			TableName:       types.StringPointerValue(table),
+			NamedCollection: types.StringNull(),
+			WithGrantOption: types.BoolNull(),
+			Revoked:         types.BoolNull(),
+		}
+		// Named collection grants are reported with the collection name in the database column.
+		if strings.Contains(accessType, "NAMED COLLECTION") {
+			grant.NamedCollection, grant.DatabaseName = grant.DatabaseName, types.StringNull()
+		}
+		if grantOption != 0 {
+			grant.WithGrantOption = types.BoolValue(true)
+		}
+		if isPartialRevoke != 0 {
+			grant.Revoked = types.BoolValue(true)
+		}
+
+		key := grant.key()
+		i, ok := index[key]
+		if !ok {
+			i = len(grants)
+			index[key] = i
+			if previous, ok := configured[key]; ok {
+				grant.Privilege = previous.Privilege
+				grant.WithGrantOption = previous.WithGrantOption
+				grant.Revoked = previous.Revoked
+			}
+			grants = append(grants, grant)
+		}
+		if column != nil {
+			grants[i].Columns = append(grants[i].Columns, types.StringValue(*column))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, true, err
+	}
+
+	for i, grant := range grants {
+		if previous, ok := configured[grant.key()]; ok {
+			grants[i].Columns = orderColumns(previous.Columns, grant.Columns)
+		}
+	}
+	return grants, true, nil
+}
+
+// readRoles returns the roles granted to the assignee.
+func (r *RoleGrants) readRoles(ctx context.Context, data *RoleGrantsModel) ([]types.String, error) {
+	rows, err := r.db.Query(ctx, readRoleGrantsRolesQuery, data.Assignee.ValueString(), data.Assignee.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []types.String
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, types.StringValue(role))
+	}
+	return roles, rows.Err()
+}
+
+// groupGrants groups the privileges, or the partial revokes when revoked is set, by target and grant option,
+// as a GRANT statement has a single target.
+func groupGrants(data *RoleGrantsModel, grants []RoleGrantsPrivilegeModel, revoked bool) (keys []string, groups map[string]GrantStatement) {
+	groups = map[string]GrantStatement{}
+	for _, grant := range grants {
+		if grant.Revoked.ValueBool() != revoked {
+			continue
+		}
+		target := grant.target(data)
+		key := fmt.Sprintf("%s:%t", grantTargetID(target), grant.WithGrantOption.ValueBool())
+		group, ok := groups[key]
+		if !ok {
+			keys = append(keys, key)
+			group = GrantStatement{GrantModel: target, GrantOption: grant.WithGrantOption.ValueBool()}
+		}
+		group.Privileges = append(group.Privileges, GrantPrivilegeModel{Privilege: grant.Privilege, Columns: grant.Columns})
+		groups[key] = group
+	}
+	return keys, groups
+}
+
+// applyGrants turns the current privileges into the planned ones. Partial revokes which are not planned are cancelled
+// first, while the broader privilege still covers them, and planned partial revokes are applied last as granting the
+// broader privilege resets them. The roles granted to the assignee are then turned into the planned ones.
+func (r *RoleGrants) applyGrants(ctx context.Context, data *RoleGrantsModel, current []RoleGrantsPrivilegeModel, currentRoles []types.String) error {
+	currentKeys, currentGroups := groupGrants(data, current, false)
+	plannedKeys, plannedGroups := groupGrants(data, data.Grants, false)
+	currentRevokeKeys, currentRevokes := groupGrants(data, current, true)
+	plannedRevokeKeys, plannedRevokes := groupGrants(data, data.Grants, true)
+
+	for _, key := range currentRevokeKeys {
+		statement := currentRevokes[key]
+		statement.Privileges = subtractPrivileges(statement.Privileges, plannedRevokes[key].Privileges)
+		if err := execGrant(ctx, r.db, ddlGrantTemplate, statement); err != nil {
+			return err
+		}
+	}
+	for _, key := range currentKeys {
+		statement := currentGroups[key]
+		statement.Privileges = subtractPrivileges(statement.Privileges, plannedGroups[key].Privileges)
+		statement.GrantOption = false
+		if err := execGrant(ctx, r.db, ddlRevokeTemplate, statement); err != nil {
+			return err
+		}
+	}
+	for _, key := range plannedKeys {
+		statement := plannedGroups[key]
+		statement.Privileges = subtractPrivileges(statement.Privileges, currentGroups[key].Privileges)
+		if err := execGrant(ctx, r.db, ddlGrantTemplate, statement); err != nil {
+			return err
+		}
+	}
+	for _, key := range plannedRevokeKeys {
+		if err := execGrant(ctx, r.db, ddlRevokeTemplate, plannedRevokes[key]); err != nil {
+			return err
+		}
+	}
+
+	assignee := []types.String{data.Assignee}
+	roles := &GrantRoleModel{ClusterName: data.ClusterName}
+	if err := execGrantRole(ctx, r.db, ddlDestroyGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: roles, Roles: subtractColumns(currentRoles, data.Roles), Grantees: assignee}); err != nil {
+		return err
+	}
+	return execGrantRole(ctx, r.db, ddlCreateGrantRoleTemplate, GrantRoleStatement{GrantRoleModel: roles, Roles: subtractColumns(data.Roles, currentRoles), Grantees: assignee})
+}
+
+func (r *RoleGrants) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RoleGrantsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, _, err := r.readGrants(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Granting Permissions",
+			"Could not read system.grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	currentRoles, err := r.readRoles(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Granting Permissions",
+			"Could not read system.role_grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if err := r.applyGrants(ctx, data, current, currentRoles); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Granting Permissions",
+			err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Assignee.ValueString())
+
+	tflog.Trace(ctx, "Created a RoleGrants Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *RoleGrants) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RoleGrantsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grants, found, err := r.readGrants(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Grants",
+			"Could not read system.grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !found {
+		tflog.Trace(ctx, "Assignee not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Grants = grants
+
+	roles, err := r.readRoles(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Grants",
+			"Could not read system.role_grants, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.Roles = roles
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *RoleGrants) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *RoleGrantsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyGrants(ctx, data, state.Grants, state.Roles); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Permissions",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrants) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RoleGrantsModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, currentRoles := data.Grants, data.Roles
+	data.Grants, data.Roles = nil, nil
+	if err := r.applyGrants(ctx, data, current, currentRoles); err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts either the assignee or the resource ID in the form cluster_name:assignee.
+func (r *RoleGrants) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterName, assignee, found := strings.Cut(req.ID, ":")
+	if !found {
+		clusterName, assignee = "", req.ID
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterName+":"+assignee)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("assignee"), assignee)...)
+	if clusterName != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
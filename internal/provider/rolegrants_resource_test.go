@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoleGrantsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleGrantsResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_role_grants.reporting", "grants.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "clickhouseops_role_grants.reporting",
+				ImportState:       true,
+				ImportStateId:     "reporting",
+				ImportStateVerify: true,
+			},
+			// Undeclared privileges are revoked, partial revokes and roles are granted
+			{
+				Config: testAccRoleGrantsResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_role_grants.reporting", "grants.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("clickhouseops_role_grants.reporting", "grants.*", map[string]string{
+						"privilege":  "SELECT",
+						"table_name": "salaries",
+						"revoked":    "true",
+					}),
+					resource.TestCheckResourceAttr("clickhouseops_role_grants.reporting", "roles.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccRoleGrantsResourceConfig = `
+resource "clickhouseops_database" "reporting" {
+	name = "reporting"
+}
+
+resource "clickhouseops_simplerole" "reporting" {
+	name = "reporting"
+}
+
+resource "clickhouseops_role_grants" "reporting" {
+	assignee = clickhouseops_simplerole.reporting.name
+	grants = [{
+		privilege = "SELECT"
+		database_name = clickhouseops_database.reporting.name
+	},{
+		privilege = "SHOW DATABASES"
+	}]
+}
+`
+	testAccRoleGrantsResourceUpdatedConfig = `
+resource "clickhouseops_database" "reporting" {
+	name = "reporting"
+}
+
+resource "clickhouseops_simplerole" "reporting" {
+	name = "reporting"
+}
+
+resource "clickhouseops_simplerole" "reporting_reader" {
+	name = "reporting_reader"
+}
+
+resource "clickhouseops_role_grants" "reporting" {
+	assignee = clickhouseops_simplerole.reporting.name
+	grants = [{
+		privilege = "SELECT"
+		database_name = clickhouseops_database.reporting.name
+	},{
+		privilege = "SELECT"
+		database_name = clickhouseops_database.reporting.name
+		table_name = "salaries"
+		revoked = true
+	}]
+	roles = [clickhouseops_simplerole.reporting_reader.name]
+}
+`
+)
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RolesDataSource{}
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+// RolesDataSource lists the roles found in system.roles.
+type RolesDataSource struct {
+	db clickhouse.Conn
+}
+
+type RolesDataSourceModel struct {
+	Id      types.String    `tfsdk:"id"`
+	Name    types.String    `tfsdk:"name"`
+	Pattern types.String    `tfsdk:"pattern"`
+	Roles   []RoleDataModel `tfsdk:"roles"`
+}
+
+type RoleDataModel struct {
+	Name    types.String `tfsdk:"name"`
+	Id      types.String `tfsdk:"id"`
+	Storage types.String `tfsdk:"storage"`
+}
+
+func (d *RolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to look up roles from system.roles, including roles created outside Terraform",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return the role with this name",
+				Optional:            true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: "Only return roles whose name matches this LIKE pattern, ie. `etl_%`",
+				Optional:            true,
+			},
+			"roles": schema.ListNestedAttribute{
+				MarkdownDescription: "Roles ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Role name",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Role UUID",
+							Computed:            true,
+						},
+						"storage": schema.StringAttribute{
+							MarkdownDescription: "Access storage the role is defined in, ie. local_directory or replicated",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readRolesDataQuery = `SELECT name, toString(id), storage FROM system.roles`
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, args := filterQuery(readRolesDataQuery,
+		queryFilter{Condition: "name = ?", Value: data.Name},
+		queryFilter{Condition: "name LIKE ?", Value: data.Pattern},
+	)
+	rows, err := d.db.Query(ctx, query+" ORDER BY name", args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Roles",
+			"Could not read system.roles, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	data.Roles = nil
+	for rows.Next() {
+		var name, id, storage string
+		if err := rows.Scan(&name, &id, &storage); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Roles",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		data.Roles = append(data.Roles, RoleDataModel{
+			Name:    types.StringValue(name),
+			Id:      types.StringValue(id),
+			Storage: types.StringValue(storage),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Roles",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(data.Name.ValueString() + ":" + data.Pattern.ValueString())
+
+	tflog.Trace(ctx, "read roles data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
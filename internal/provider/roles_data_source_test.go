@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRolesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRolesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_roles.by_name", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_roles.by_name", "roles.0.name", "lookup_role_a"),
+					resource.TestCheckResourceAttr("data.clickhouseops_roles.by_pattern", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.clickhouseops_roles.by_pattern", "roles.1.name", "lookup_role_b"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRolesDataSourceConfig = `
+resource "clickhouseops_simplerole" "lookup_role_a" {
+  name = "lookup_role_a"
+}
+
+resource "clickhouseops_simplerole" "lookup_role_b" {
+  name = "lookup_role_b"
+}
+
+data "clickhouseops_roles" "by_name" {
+  name = clickhouseops_simplerole.lookup_role_a.name
+}
+
+data "clickhouseops_roles" "by_pattern" {
+  pattern = "lookup\\_role\\_%"
+  depends_on = [clickhouseops_simplerole.lookup_role_a, clickhouseops_simplerole.lookup_role_b]
+}
+`
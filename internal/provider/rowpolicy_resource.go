@@ -0,0 +1,411 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+var (
+	_ resource.Resource                   = &RowPolicy{}
+	_ resource.ResourceWithConfigure      = &RowPolicy{}
+	_ resource.ResourceWithImportState    = &RowPolicy{}
+	_ resource.ResourceWithValidateConfig = &RowPolicy{}
+)
+
+func NewRowPolicy() resource.Resource {
+	return &RowPolicy{}
+}
+
+type RowPolicy struct {
+	db     clickhouse.Conn
+	server *ServerInfo
+}
+
+type RowPolicyModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	ClusterName  types.String   `tfsdk:"cluster_name"`
+	DatabaseName types.String   `tfsdk:"database_name"`
+	TableName    types.String   `tfsdk:"table_name"`
+	As           types.String   `tfsdk:"as"`
+	Using        types.String   `tfsdk:"using"`
+	To           []types.String `tfsdk:"to"`
+}
+
+func (r *RowPolicy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_row_policy"
+}
+
+func (r *RowPolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse row policy filtering the rows a user or role can select from a table",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse row policy name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse cluster name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the database where the table is located",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table the policy applies to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"as": schema.StringAttribute{
+				MarkdownDescription: "Policy kind, PERMISSIVE or RESTRICTIVE. Defaults to PERMISSIVE",
+				Optional:            true,
+			},
+			"using": schema.StringAttribute{
+				MarkdownDescription: "Condition the selected rows must satisfy",
+				Required:            true,
+			},
+			"to": schema.SetAttribute{
+				MarkdownDescription: "Users or roles the policy applies to",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *RowPolicy) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+	r.server = serverInfo(req.ProviderData)
+}
+
+func (r *RowPolicy) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RowPolicyModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.As.IsNull() && !data.As.IsUnknown() {
+		switch strings.ToUpper(data.As.ValueString()) {
+		case "PERMISSIVE", "RESTRICTIVE":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("as"),
+				"Invalid Attribute Configuration",
+				"Expect as to be PERMISSIVE or RESTRICTIVE, got: "+data.As.ValueString(),
+			)
+		}
+	}
+}
+
+/*
+	Clickhouse Row Policy Syntax for reference
+
+CREATE [ROW] POLICY [IF NOT EXISTS | OR REPLACE] policy_name1 [ON CLUSTER cluster_name1] ON [db1.]table1|db1.*
+
+	[, policy_name2 [ON CLUSTER cluster_name2] ON [db2.]table2|db2.* ...]
+	[IN access_storage_type]
+	[FOR SELECT] USING condition
+	[AS {PERMISSIVE | RESTRICTIVE}]
+	[TO {role1 [, role2 ...] | ALL | ALL EXCEPT role1 [, role2 ...]}]
+*/
+const ddlCreateRowPolicyTemplate = `
+{{if .Alter}}ALTER{{else}}CREATE{{end}} ROW POLICY "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} ON "{{.DatabaseName.ValueString}}"."{{.TableName.ValueString}}"
+FOR SELECT USING {{.Using.ValueString}}
+AS {{if .As.IsNull}}PERMISSIVE{{else}}{{.As.ValueString}}{{end}}
+{{$size := size .To}}
+{{if .To}}TO {{range $i, $e := .To}}'{{$e.ValueString}}'{{if lt $i $size}},{{end}}{{end}}{{else if .Alter}}TO NONE{{end}}
+`
+
+/*
+DROP [ROW] POLICY [IF EXISTS] name [,...] ON [database.]table [,...] [ON CLUSTER cluster_name] [FROM access_storage_type]
+.
+*/
+const ddlDropRowPolicyTemplate = `
+DROP ROW POLICY IF EXISTS "{{.Name.ValueString}}" ON "{{.DatabaseName.ValueString}}"."{{.TableName.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+`
+
+const readRowPolicyQuery = `
+SELECT select_filter, is_restrictive, apply_to_list
+FROM system.row_policies
+WHERE short_name = ? AND database = ? AND table = ?
+`
+
+// sameExpressionQuery compares two expressions as formatted by the server, formatQuerySingleLine only accepts queries.
+const sameExpressionQuery = `SELECT formatQuerySingleLine(concat('SELECT ', ?)) = formatQuerySingleLine(concat('SELECT ', ?))`
+
+type RowPolicyStatement struct {
+	*RowPolicyModel
+	Alter bool
+}
+
+func (r *RowPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RowPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateRowPolicyTemplate, RowPolicyStatement{RowPolicyModel: data})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Row Policy",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Row Policy",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.DatabaseName.ValueString() + ":" + data.TableName.ValueString() + ":" + data.Name.ValueString())
+
+	tflog.Trace(ctx, "Created a Row Policy Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *RowPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RowPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := r.db.Query(ctx, readRowPolicyQuery, data.Name.ValueString(), data.DatabaseName.ValueString(), data.TableName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Row Policy",
+			"Could not read system.row_policies, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		tflog.Trace(ctx, "Row Policy not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var selectFilter *string
+	var isRestrictive uint8
+	var applyTo []string
+	if err := rows.Scan(&selectFilter, &isRestrictive, &applyTo); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Row Policy",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// The server stores the condition formatted, keep the configured one unless it really changed.
+	if selectFilter != nil {
+		same, err := r.sameExpression(ctx, *selectFilter, data.Using)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Row Policy",
+				"Could not format the row policy condition, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if !same {
+			data.Using = types.StringValue(*selectFilter)
+		}
+	}
+
+	kind := "PERMISSIVE"
+	if isRestrictive != 0 {
+		kind = "RESTRICTIVE"
+	}
+	if !strings.EqualFold(data.As.ValueString(), kind) && !(data.As.IsNull() && isRestrictive == 0) {
+		data.As = types.StringValue(kind)
+	}
+	data.To = stringValues(applyTo)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// sameExpression reports whether the stored condition is the configured one once both are formatted by the server.
+// Servers older than 23.10 lack formatQuerySingleLine, both are then compared through normalizeExpression.
+func (r *RowPolicy) sameExpression(ctx context.Context, stored string, configured types.String) (bool, error) {
+	if configured.IsNull() {
+		return false, nil
+	}
+	if r.server != nil && !r.server.AtLeast(23, 10) {
+		return normalizeExpression(stored) == normalizeExpression(configured.ValueString()), nil
+	}
+
+	var same uint8
+	if err := r.db.QueryRow(ctx, sameExpressionQuery, stored, configured.ValueString()).Scan(&same); err != nil {
+		return false, err
+	}
+	return same != 0, nil
+}
+
+// normalizeExpression removes whitespace and identifier quoting outside of string literals, which are kept as is.
+func normalizeExpression(expression string) string {
+	var normalized strings.Builder
+	inLiteral, escaped := false, false
+	for _, c := range expression {
+		switch {
+		case inLiteral:
+			normalized.WriteRune(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '\'':
+				inLiteral = false
+			}
+		case c == '\'':
+			inLiteral = true
+			normalized.WriteRune(c)
+		case c == ' ' || c == '\n' || c == '\t' || c == '`' || c == '"':
+		default:
+			normalized.WriteRune(c)
+		}
+	}
+	return normalized.String()
+}
+
+func (r *RowPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RowPolicyModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateRowPolicyTemplate, RowPolicyStatement{RowPolicyModel: data, Alter: true})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Row Policy",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Row Policy",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RowPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RowPolicyModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlDropRowPolicyTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts an ID in the form [cluster_name:]database_name:table_name:name.
+func (r *RowPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) == 3 {
+		parts = append([]string{""}, parts...)
+	}
+	if len(parts) != 4 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier with format: [cluster_name:]database_name:table_name:name, got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(parts, ":"))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[3])...)
+	if parts[0] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), parts[0])...)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRowPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRowPolicyResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_row_policy.tenant_42", "using", "tenant_id = 42"),
+					resource.TestCheckResourceAttr("clickhouseops_row_policy.tenant_42", "to.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "clickhouseops_row_policy.tenant_42",
+				ImportState:       true,
+				ImportStateId:     "tenants:events:tenant_42",
+				ImportStateVerify: true,
+			},
+			// Update in place
+			{
+				Config: testAccRowPolicyResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_row_policy.tenant_42", "using", "tenant_id IN (42, 43)"),
+					resource.TestCheckResourceAttr("clickhouseops_row_policy.tenant_42", "as", "RESTRICTIVE"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccRowPolicyResourceConfig = `
+resource "clickhouseops_database" "tenants" {
+	name = "tenants"
+}
+
+resource "clickhouseops_mergetree" "events" {
+	name = "events"
+	database_name = clickhouseops_database.tenants.name
+	columns = [{
+		name = "tenant_id"
+		type = "UInt64"
+	},{
+		name = "payload"
+		type = "String"
+	}]
+	order_by = ["tenant_id"]
+}
+
+resource "clickhouseops_simplerole" "tenant_42" {
+	name = "tenant_42"
+}
+
+resource "clickhouseops_row_policy" "tenant_42" {
+	name = "tenant_42"
+	database_name = clickhouseops_database.tenants.name
+	table_name = clickhouseops_mergetree.events.name
+	using = "tenant_id = 42"
+	to = [clickhouseops_simplerole.tenant_42.name]
+}
+`
+	testAccRowPolicyResourceUpdatedConfig = `
+resource "clickhouseops_database" "tenants" {
+	name = "tenants"
+}
+
+resource "clickhouseops_mergetree" "events" {
+	name = "events"
+	database_name = clickhouseops_database.tenants.name
+	columns = [{
+		name = "tenant_id"
+		type = "UInt64"
+	},{
+		name = "payload"
+		type = "String"
+	}]
+	order_by = ["tenant_id"]
+}
+
+resource "clickhouseops_simplerole" "tenant_42" {
+	name = "tenant_42"
+}
+
+resource "clickhouseops_row_policy" "tenant_42" {
+	name = "tenant_42"
+	database_name = clickhouseops_database.tenants.name
+	table_name = clickhouseops_mergetree.events.name
+	as = "RESTRICTIVE"
+	using = "tenant_id IN (42, 43)"
+	to = [clickhouseops_simplerole.tenant_42.name]
+}
+`
+)
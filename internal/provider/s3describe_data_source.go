@@ -6,10 +6,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
@@ -24,21 +27,28 @@ func NewS3DescribeDataSource() datasource.DataSource {
 
 // S3DescribeDataSource defines the data source implementation.
 type S3DescribeDataSource struct {
-	db clickhouse.Conn
+	db      clickhouse.Conn
+	secrets *Secrets
 }
 
 // S3DescribeDataSourceModel describes the data source data model.
 
 type S3DescribeDataSourceModel struct {
-	Id                  types.String       `tfsdk:"id"`
-	NamedCollectionName types.String       `tfsdk:"named_collection_name"`
-	Path                types.String       `tfsdk:"path"`
-	NoSign              types.Bool         `tfsdk:"nosign"`
-	AwsAccessKeyId      types.String       `tfsdk:"aws_access_key_id"`
-	AwsSecretAccessKey  types.String       `tfsdk:"aws_secret_access_key"`
-	Format              types.String       `tfsdk:"format"`
-	Compression         types.String       `tfsdk:"compression"`
-	ClickhouseColumns   []ClickhouseColumn `tfsdk:"clickhouseops_columns"`
+	Id                    types.String            `tfsdk:"id"`
+	NamedCollectionName   types.String            `tfsdk:"named_collection_name"`
+	Path                  types.String            `tfsdk:"path"`
+	NoSign                types.Bool              `tfsdk:"nosign"`
+	AwsAccessKeyId        types.String            `tfsdk:"aws_access_key_id"`
+	AwsSecretAccessKey    types.String            `tfsdk:"aws_secret_access_key"`
+	SecretAccessKeySecret types.String            `tfsdk:"aws_secret_access_key_secret"`
+	Format                types.String            `tfsdk:"format"`
+	Compression           types.String            `tfsdk:"compression"`
+	Structure             types.String            `tfsdk:"structure"`
+	SchemaInferenceHints  types.String            `tfsdk:"schema_inference_hints"`
+	Settings              map[string]types.String `tfsdk:"settings"`
+	StripNullable         types.Bool              `tfsdk:"strip_nullable"`
+	LowCardinality        types.Bool              `tfsdk:"low_cardinality_strings"`
+	ClickhouseColumns     []TableColumnDataModel  `tfsdk:"clickhouseops_columns"`
 }
 
 type ClickhouseColumn struct {
@@ -46,6 +56,11 @@ type ClickhouseColumn struct {
 	Type types.String `tfsdk:"type"`
 }
 
+var clickhouseColumnAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"type": types.StringType,
+}
+
 type Column struct {
 	Name              string
 	Type              string
@@ -87,7 +102,12 @@ func (d *S3DescribeDataSource) Schema(ctx context.Context, req datasource.Schema
 				Optional:            true,
 			},
 			"aws_secret_access_key": schema.StringAttribute{
-				MarkdownDescription: "aws_secret_access_key with permission for s3",
+				MarkdownDescription: "aws_secret_access_key with permission for s3, stored in state. Conflicts with aws_secret_access_key_secret",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"aws_secret_access_key_secret": schema.StringAttribute{
+				MarkdownDescription: "Name of the provider secret holding aws_secret_access_key, which is not stored in state. Conflicts with aws_secret_access_key",
 				Optional:            true,
 			},
 			"format": schema.StringAttribute{
@@ -98,8 +118,30 @@ func (d *S3DescribeDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "data compression format, ie. gzip, zip, etc.",
 				Optional:            true,
 			},
+			"structure": schema.StringAttribute{
+				MarkdownDescription: "Structure of the data, ie. `a UInt32, b String`, skips schema inference when set",
+				Optional:            true,
+			},
+			"schema_inference_hints": schema.StringAttribute{
+				MarkdownDescription: "Types of some of the columns, ie. `a UInt32, d Date`, the other ones are still inferred",
+				Optional:            true,
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "Query settings used to infer the schema, ie. `input_format_max_rows_to_read_for_schema_inference`, " +
+					"`schema_inference_make_columns_nullable` or `input_format_try_infer_dates`",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"strip_nullable": schema.BoolAttribute{
+				MarkdownDescription: "If it is true Nullable() is removed from the inferred types",
+				Optional:            true,
+			},
+			"low_cardinality_strings": schema.BoolAttribute{
+				MarkdownDescription: "If it is true String columns are wrapped in LowCardinality()",
+				Optional:            true,
+			},
 			"clickhouseops_columns": schema.ListNestedAttribute{
-				MarkdownDescription: "PostgreSQL columns converted to Clickhouse columns",
+				MarkdownDescription: "Columns inferred from the S3 data converted to Clickhouse columns",
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -111,6 +153,26 @@ func (d *S3DescribeDataSource) Schema(ctx context.Context, req datasource.Schema
 							MarkdownDescription: "Clickhouse type",
 							Computed:            true,
 						},
+						"default_kind": schema.StringAttribute{
+							MarkdownDescription: "DEFAULT, MATERIALIZED, ALIAS or EPHEMERAL, null when the column has no default",
+							Computed:            true,
+						},
+						"default_expression": schema.StringAttribute{
+							MarkdownDescription: "Default expression, null when the column has no default",
+							Computed:            true,
+						},
+						"codec": schema.StringAttribute{
+							MarkdownDescription: "Compression codec, null when not set",
+							Computed:            true,
+						},
+						"ttl": schema.StringAttribute{
+							MarkdownDescription: "Column TTL expression, null when not set",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Column comment, null when not set",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -135,10 +197,12 @@ func (d *S3DescribeDataSource) Configure(ctx context.Context, req datasource.Con
 	}
 
 	d.db = db
+	d.secrets = providerSecrets(req.ProviderData)
 }
 
 /*
 DESCRIBE s3(path [, NOSIGN | aws_access_key_id, aws_secret_access_key [,session_token]] [,format] [,structure] [,compression])
+[SETTINGS name = value, ...]
 */
 
 const s3DescribeTemplate = `
@@ -149,15 +213,21 @@ DESCRIBE s3(
 	{{if not .AwsAccessKeyId.IsNull}},aws_access_key_id='{{.AwsAccessKeyId.ValueString}}'{{end}}
 	{{if not .AwsSecretAccessKey.IsNull}},aws_secret_access_key='{{.AwsSecretAccessKey.ValueString}}'{{end}}
 	{{if not .Format.IsNull}},format='{{.Format.ValueString}}'{{end}}
+	{{if not .Structure.IsNull}},structure='{{.Structure.ValueString}}'{{end}}
 	{{if not .Compression.IsNull}},compression='{{.Compression.ValueString}}'{{end}}
 	{{else}}
 	'{{.Path.ValueString}}'
 	{{if not .NoSign.IsNull}}{{if .NoSign.ValueBool}},NOSIGN{{end}}{{end}}
 	{{if not .AwsAccessKeyId.IsNull}},'{{.AwsAccessKeyId.ValueString}}'{{end}}
 	{{if not .AwsSecretAccessKey.IsNull}},'{{.AwsSecretAccessKey.ValueString}}'{{end}}
-	{{if not .Format.IsNull}},'{{.Format.ValueString}}'{{end}}
+	{{if not .Format.IsNull}},'{{.Format.ValueString}}'{{else if or (not .Structure.IsNull) (not .Compression.IsNull)}},'auto'{{end}}
+	{{if not .Structure.IsNull}},'{{.Structure.ValueString}}'{{else if not .Compression.IsNull}},'auto'{{end}}
 	{{if not .Compression.IsNull}},'{{.Compression.ValueString}}'{{end}}
 	{{end}})
+{{if or .Settings (not .SchemaInferenceHints.IsNull)}}SETTINGS
+	{{if not .SchemaInferenceHints.IsNull}}schema_inference_hints='{{.SchemaInferenceHints.ValueString}}'{{end}}
+	{{$first := .SchemaInferenceHints.IsNull}}{{range $name, $value := .Settings}}{{if not $first}},{{end}}{{$first = false}}{{$name}}='{{$value.ValueString}}'{{end}}
+{{end}}
 `
 
 func (d *S3DescribeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -178,7 +248,28 @@ func (d *S3DescribeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	query, err := common.RenderTemplate(s3DescribeTemplate, data)
+	if !data.AwsSecretAccessKey.IsNull() && !data.SecretAccessKeySecret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("aws_secret_access_key_secret"),
+			"Invalid Attribute Configuration",
+			"Expect only one of aws_secret_access_key or aws_secret_access_key_secret to be set",
+		)
+		return
+	}
+
+	secretAccessKey, diags := d.secrets.Resolve(path.Root("aws_secret_access_key_secret"), data.SecretAccessKeySecret)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The secret is only rendered into the query, the model written to state keeps the name.
+	statement := data
+	if !secretAccessKey.IsNull() {
+		statement.AwsSecretAccessKey = secretAccessKey
+	}
+
+	query, err := common.RenderTemplate(s3DescribeTemplate, statement)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error trying to get Clickhouse columns from S3 path",
@@ -187,7 +278,7 @@ func (d *S3DescribeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	rows, err := d.db.Query(ctx, *query)
+	described, err := describeColumns(ctx, d.db, *query)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error trying to get Clickhouse columns from S3 path",
@@ -195,23 +286,17 @@ func (d *S3DescribeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		)
 		return
 	}
-	defer rows.Close()
 
-	var columns []ClickhouseColumn
-	for rows.Next() {
-		var col Column
-		if err := rows.Scan(&col.Name, &col.Type, &col.DefaultType, &col.DefaultExpression,
-			&col.Comment, &col.CodecExpression, &col.TTLExpression); err != nil {
-			resp.Diagnostics.AddError(
-				"Error trying to get Clickhouse columns from S3 path",
-				"Could not retrieve Rows: unexpected error: "+err.Error(),
-			)
-			return
-		}
-
-		columns = append(columns, ClickhouseColumn{
-			Name: types.StringValue(col.Name),
-			Type: types.StringValue(col.Type),
+	var columns []TableColumnDataModel
+	for _, col := range described {
+		columns = append(columns, TableColumnDataModel{
+			Name:              types.StringValue(col.Name),
+			Type:              types.StringValue(inferredType(col.Type, data.StripNullable.ValueBool(), data.LowCardinality.ValueBool())),
+			DefaultKind:       stringOrNull(col.DefaultType),
+			DefaultExpression: stringOrNull(col.DefaultExpression),
+			Codec:             stringOrNull(col.CodecExpression),
+			TTL:               stringOrNull(col.TTLExpression),
+			Comment:           stringOrNull(col.Comment),
 		})
 	}
 
@@ -225,3 +310,54 @@ func (d *S3DescribeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// inferredType removes every Nullable() from columnType when stripNullable is set,
+// then wraps String in LowCardinality() when lowCardinality is set.
+func inferredType(columnType string, stripNullable bool, lowCardinality bool) string {
+	for stripNullable {
+		start := strings.Index(columnType, "Nullable(")
+		if start < 0 {
+			break
+		}
+		depth, end := 0, -1
+		for i := start + len("Nullable"); i < len(columnType) && end < 0; i++ {
+			switch columnType[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			break
+		}
+		columnType = columnType[:start] + columnType[start+len("Nullable("):end] + columnType[end+1:]
+	}
+	if lowCardinality && (columnType == "String" || columnType == "Nullable(String)") {
+		columnType = "LowCardinality(" + columnType + ")"
+	}
+	return columnType
+}
+
+// describeColumns runs a DESCRIBE query and returns every column it reports.
+func describeColumns(ctx context.Context, db clickhouse.Conn, query string) ([]Column, error) {
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []Column
+	for rows.Next() {
+		var col Column
+		if err := rows.Scan(&col.Name, &col.Type, &col.DefaultType, &col.DefaultExpression,
+			&col.Comment, &col.CodecExpression, &col.TTLExpression); err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
@@ -32,6 +32,13 @@ func TestAccS3DescribeDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.clickhouseops_s3describe.addresses", "clickhouseops_columns.5.type", "Nullable(Int64)"),
 				),
 			},
+			{
+				Config: testAccS3DescribeInferenceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_s3describe.addresses", "clickhouseops_columns.0.type", "LowCardinality(String)"),
+					resource.TestCheckResourceAttr("data.clickhouseops_s3describe.addresses", "clickhouseops_columns.5.type", "UInt32"),
+				),
+			},
 		},
 	})
 }
@@ -44,3 +51,18 @@ data "clickhouseops_s3describe" "addresses" {
 	format = "CSV"
 }
 `
+
+const testAccS3DescribeInferenceConfig = `
+data "clickhouseops_s3describe" "addresses" {
+	path = "http://minio:9000/test/addresses.csv"
+	aws_access_key_id = "minioadmin"
+	aws_secret_access_key = "minioadmin"
+	format = "CSV"
+	schema_inference_hints = "c6 UInt32"
+	settings = {
+		input_format_max_rows_to_read_for_schema_inference = "1000"
+	}
+	strip_nullable = true
+	low_cardinality_strings = true
+}
+`
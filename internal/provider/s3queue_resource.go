@@ -20,9 +20,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &S3Queue{}
-	_ resource.ResourceWithConfigure   = &S3Queue{}
-	_ resource.ResourceWithImportState = &S3Queue{}
+	_ resource.Resource                   = &S3Queue{}
+	_ resource.ResourceWithConfigure      = &S3Queue{}
+	_ resource.ResourceWithImportState    = &S3Queue{}
+	_ resource.ResourceWithValidateConfig = &S3Queue{}
+	_ resource.ResourceWithModifyPlan     = &S3Queue{}
 )
 
 func NewS3Queue() resource.Resource {
@@ -30,23 +32,27 @@ func NewS3Queue() resource.Resource {
 }
 
 type S3Queue struct {
-	db clickhouse.Conn
+	db      clickhouse.Conn
+	server  *ServerInfo
+	secrets *Secrets
 }
 
 type S3QueueModel struct {
-	ID                  types.String           `tfsdk:"id"`
-	Name                types.String           `tfsdk:"name"`
-	DatabaseName        types.String           `tfsdk:"database_name"`
-	ClusterName         types.String           `tfsdk:"cluster_name"`
-	Columns             []S3QueueColumnsModel  `tfsdk:"columns"`
-	NamedCollectionName types.String           `tfsdk:"named_collection_name"`
-	Path                types.String           `tfsdk:"path"`
-	NoSign              types.Bool             `tfsdk:"nosign"`
-	AwsAccessKeyId      types.String           `tfsdk:"aws_access_key_id"`
-	AwsSecretAccessKey  types.String           `tfsdk:"aws_secret_access_key"`
-	Format              types.String           `tfsdk:"format"`
-	Compression         types.String           `tfsdk:"compression"`
-	Settings            []S3QueueSettingsModel `tfsdk:"settings"`
+	ID                        types.String           `tfsdk:"id"`
+	Name                      types.String           `tfsdk:"name"`
+	DatabaseName              types.String           `tfsdk:"database_name"`
+	ClusterName               types.String           `tfsdk:"cluster_name"`
+	Columns                   []S3QueueColumnsModel  `tfsdk:"columns"`
+	NamedCollectionName       types.String           `tfsdk:"named_collection_name"`
+	Path                      types.String           `tfsdk:"path"`
+	NoSign                    types.Bool             `tfsdk:"nosign"`
+	AwsAccessKeyId            types.String           `tfsdk:"aws_access_key_id"`
+	AwsSecretAccessKey        types.String           `tfsdk:"aws_secret_access_key"`
+	SecretAccessKeySecret     types.String           `tfsdk:"aws_secret_access_key_secret"`
+	SecretAccessKeySecretHash types.String           `tfsdk:"aws_secret_access_key_secret_hash"`
+	Format                    types.String           `tfsdk:"format"`
+	Compression               types.String           `tfsdk:"compression"`
+	Settings                  []S3QueueSettingsModel `tfsdk:"settings"`
 }
 
 type S3QueueColumnsModel struct {
@@ -141,12 +147,24 @@ func (r *S3Queue) Schema(ctx context.Context, req resource.SchemaRequest, resp *
 				},
 			},
 			"aws_secret_access_key": schema.StringAttribute{
-				MarkdownDescription: "aws_secret_access_key to access s3 bucket3",
+				MarkdownDescription: "aws_secret_access_key to access s3 bucket3, stored in state. Conflicts with aws_secret_access_key_secret",
 				Optional:            true,
+				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"aws_secret_access_key_secret": schema.StringAttribute{
+				MarkdownDescription: "Name of the provider secret holding aws_secret_access_key, which is not stored in state. Conflicts with aws_secret_access_key",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aws_secret_access_key_secret_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the key read from aws_secret_access_key_secret, a rotated key replaces the table",
+				Computed:            true,
+			},
 			"format": schema.StringAttribute{
 				MarkdownDescription: "S3Queue format config",
 				Optional:            true,
@@ -199,6 +217,39 @@ func (r *S3Queue) Configure(ctx context.Context, req resource.ConfigureRequest,
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+	r.secrets = providerSecrets(req.ProviderData)
+}
+
+func (r *S3Queue) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data S3QueueModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.AwsSecretAccessKey.IsNull() && !data.SecretAccessKeySecret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("aws_secret_access_key_secret"),
+			"Invalid Attribute Configuration",
+			"Expect only one of aws_secret_access_key or aws_secret_access_key_secret to be set",
+		)
+	}
+}
+
+// ModifyPlan fails the plan when the server does not support the S3Queue engine, and replaces the table
+// when the secret access key was rotated.
+func (r *S3Queue) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkFeature(featureS3Queue)...)
+
+	if r.secrets.planSecretHash(ctx, req, resp, "aws_secret_access_key_secret", "aws_secret_access_key_secret_hash") {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("aws_secret_access_key_secret_hash"))
+	}
 }
 
 /* Clickhouse S3Queue Syntax for reference
@@ -271,7 +322,20 @@ func (r *S3Queue) Create(ctx context.Context, req resource.CreateRequest, resp *
 		return
 	}
 
-	query, err := common.RenderTemplate(queryS3QueueTemplate, data)
+	secretAccessKey, diags := r.secrets.Resolve(path.Root("aws_secret_access_key_secret"), data.SecretAccessKeySecret)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SecretAccessKeySecretHash = secretHash(secretAccessKey)
+
+	// The secret is only rendered into the DDL, the model written to state keeps the name.
+	statement := *data
+	if !secretAccessKey.IsNull() {
+		statement.AwsSecretAccessKey = secretAccessKey
+	}
+
+	query, err := common.RenderTemplate(queryS3QueueTemplate, statement)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse S3Queue Table",
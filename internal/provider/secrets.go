@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Secrets holds the secrets attribute of the provider configuration. Resources reference them by name from
+// *_secret attributes: values are only rendered into the DDL sent to Clickhouse and state keeps their SHA256.
+// The provider configuration accepts ephemeral values, so secrets can come from ephemeral resources reading
+// a secrets manager without ever being persisted.
+type Secrets struct {
+	// values is nil while the secrets attribute is unknown, ie. during a plan depending on resources to create.
+	values map[string]types.String
+}
+
+// providerSecrets returns the secrets configured on the provider, none when the provider data carries none.
+func providerSecrets(providerData any) *Secrets {
+	if conn, ok := providerData.(*ServerConn); ok && conn.Secrets != nil {
+		return conn.Secrets
+	}
+	return &Secrets{values: map[string]types.String{}}
+}
+
+// Lookup returns the secret named by name: null when name is null and unknown while either is unknown.
+// A name missing from the provider secrets is reported against attribute.
+func (s *Secrets) Lookup(attribute path.Path, name types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if name.IsNull() {
+		return types.StringNull(), diags
+	}
+	if name.IsUnknown() || s == nil || s.values == nil {
+		return types.StringUnknown(), diags
+	}
+	value, ok := s.values[name.ValueString()]
+	if !ok || value.IsNull() {
+		diags.AddAttributeError(
+			attribute,
+			"Invalid Attribute Configuration",
+			"Expect secret "+name.ValueString()+" to be set in the provider secrets",
+		)
+		return types.StringUnknown(), diags
+	}
+	return value, diags
+}
+
+// Resolve is Lookup when applying, a secret still unknown is reported against attribute.
+func (s *Secrets) Resolve(attribute path.Path, name types.String) (types.String, diag.Diagnostics) {
+	value, diags := s.Lookup(attribute, name)
+	if !diags.HasError() && value.IsUnknown() {
+		diags.AddAttributeError(
+			attribute,
+			"Unknown Secret",
+			"Could not resolve secret "+name.ValueString()+", the provider secrets are not known",
+		)
+	}
+	return value, diags
+}
+
+// secretHash returns the hex encoded SHA256 of value, null when value is null and unknown while it is unknown.
+func secretHash(value types.String) types.String {
+	if value.IsNull() || value.IsUnknown() {
+		return value
+	}
+	hash := sha256.Sum256([]byte(value.ValueString()))
+	return types.StringValue(hex.EncodeToString(hash[:]))
+}
+
+// planSecretHash sets hashAttribute in the plan to the SHA256 of the secret named by secretAttribute and
+// reports whether it differs from the state, ie. the secret was rotated since the last apply.
+func (s *Secrets) planSecretHash(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, secretAttribute string, hashAttribute string) bool {
+	var name, planned, current types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(secretAttribute), &name)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	value, diags := s.Lookup(path.Root(secretAttribute), name)
+	resp.Diagnostics.Append(diags...)
+	planned = secretHash(value)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(hashAttribute), planned)...)
+
+	if req.State.Raw.IsNull() || planned.IsUnknown() {
+		return false
+	}
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(hashAttribute), &current)...)
+	return !planned.Equal(current)
+}
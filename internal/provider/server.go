@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ServerConn is handed to resources and data sources as provider data. It embeds the connection,
+// so asserting clickhouse.Conn keeps working, and carries what Configure detected about the server
+// along with the provider secrets.
+type ServerConn struct {
+	clickhouse.Conn
+	Info    *ServerInfo
+	Secrets *Secrets
+}
+
+// ServerInfo describes the server version, build options and known settings.
+// A nil *ServerInfo means detection failed and no check is made.
+type ServerInfo struct {
+	Version           string
+	Major             int
+	Minor             int
+	BuildOptions      map[string]string
+	Settings          map[string]bool
+	MergeTreeSettings map[string]bool
+}
+
+// serverFeature is a capability depending on the server version and, when BuildOption is set, on a build option.
+type serverFeature struct {
+	Key         string
+	Name        string
+	Major       int
+	Minor       int
+	BuildOption string
+}
+
+var (
+	featureS3Queue                     = serverFeature{Key: "s3queue", Name: "S3Queue table engine", Major: 23, Minor: 8, BuildOption: "USE_AWS_S3"}
+	featureRefreshableMaterializedView = serverFeature{Key: "refreshable_materialized_view", Name: "Refreshable materialized views", Major: 23, Minor: 12}
+	featureNamedCollectionDDL          = serverFeature{Key: "named_collection_ddl", Name: "NAMED COLLECTION DDL", Major: 22, Minor: 12}
+	featureKafka                       = serverFeature{Key: "kafka", Name: "Kafka table engine", BuildOption: "USE_RDKAFKA"}
+	featurePostgreSQL                  = serverFeature{Key: "postgresql", Name: "PostgreSQL table engine", BuildOption: "USE_LIBPQXX"}
+	featureSQLSecurity                 = serverFeature{Key: "sql_security", Name: "DEFINER and SQL SECURITY on views", Major: 24, Minor: 2}
+)
+
+var serverFeatures = []serverFeature{
+	featureS3Queue,
+	featureRefreshableMaterializedView,
+	featureNamedCollectionDDL,
+	featureKafka,
+	featurePostgreSQL,
+	featureSQLSecurity,
+}
+
+const (
+	readServerVersionQuery         = `SELECT version()`
+	readBuildOptionsQuery          = `SELECT name, value FROM system.build_options`
+	readSettingNamesQuery          = `SELECT name FROM system.settings`
+	readMergeTreeSettingNamesQuery = `SELECT name FROM system.merge_tree_settings`
+)
+
+// detectServer reads version(), system.build_options and the names of the settings known by the server.
+func detectServer(ctx context.Context, db clickhouse.Conn) (*ServerInfo, error) {
+	info := &ServerInfo{BuildOptions: map[string]string{}}
+	if err := db.QueryRow(ctx, readServerVersionQuery).Scan(&info.Version); err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(info.Version, ".", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("unexpected version format: %s", info.Version)
+	}
+	var err error
+	if info.Major, err = strconv.Atoi(parts[0]); err != nil {
+		return nil, fmt.Errorf("unexpected version format: %s", info.Version)
+	}
+	if info.Minor, err = strconv.Atoi(parts[1]); err != nil {
+		return nil, fmt.Errorf("unexpected version format: %s", info.Version)
+	}
+
+	rows, err := db.Query(ctx, readBuildOptionsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		info.BuildOptions[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if info.Settings, err = readNames(ctx, db, readSettingNamesQuery); err != nil {
+		return nil, err
+	}
+	if info.MergeTreeSettings, err = readNames(ctx, db, readMergeTreeSettingNamesQuery); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func readNames(ctx context.Context, db clickhouse.Conn, query string) (map[string]bool, error) {
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
+
+// serverInfo returns what the provider detected about the server, nil when unknown.
+func serverInfo(providerData any) *ServerInfo {
+	if conn, ok := providerData.(*ServerConn); ok {
+		return conn.Info
+	}
+	return nil
+}
+
+// AtLeast reports whether the server version is major.minor or newer.
+func (s *ServerInfo) AtLeast(major int, minor int) bool {
+	return s.Major > major || (s.Major == major && s.Minor >= minor)
+}
+
+// Supports returns why feature is not supported by the server, an empty string when it is.
+// A build option missing from system.build_options is not held against the server.
+func (s *ServerInfo) Supports(feature serverFeature) string {
+	if !s.AtLeast(feature.Major, feature.Minor) {
+		return fmt.Sprintf("%s requires Clickhouse %d.%d or newer, the server runs %s", feature.Name, feature.Major, feature.Minor, s.Version)
+	}
+	if value, ok := s.BuildOptions[feature.BuildOption]; feature.BuildOption != "" && ok {
+		switch strings.ToUpper(value) {
+		case "0", "OFF", "FALSE":
+			return fmt.Sprintf("%s requires a server built with %s, the server has %s=%s", feature.Name, feature.BuildOption, feature.BuildOption, value)
+		}
+	}
+	return ""
+}
+
+// checkFeature returns an error diagnostic when the server is known not to support feature.
+func (s *ServerInfo) checkFeature(feature serverFeature) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if s == nil {
+		return diags
+	}
+	if reason := s.Supports(feature); reason != "" {
+		diags.AddError("Unsupported Clickhouse Feature", reason)
+	}
+	return diags
+}
+
+// checkSettings returns an error diagnostic for every planned setting the server does not know. The settings
+// list attribute holds objects with a name attribute; unknown names and names starting with custom_ are skipped.
+// Table settings are looked up in system.merge_tree_settings when mergeTree is set.
+func (s *ServerInfo) checkSettings(ctx context.Context, plan tfsdk.Plan, mergeTree bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if s == nil {
+		return diags
+	}
+
+	var settings types.List
+	diags.Append(plan.GetAttribute(ctx, path.Root("settings"), &settings)...)
+	if diags.HasError() || settings.IsNull() || settings.IsUnknown() {
+		return diags
+	}
+
+	known := s.Settings
+	if mergeTree {
+		known = s.MergeTreeSettings
+	}
+	for i, element := range settings.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		name, ok := object.Attributes()["name"].(types.String)
+		if !ok || name.IsUnknown() || known[name.ValueString()] || strings.HasPrefix(name.ValueString(), "custom_") {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root("settings").AtListIndex(i).AtName("name"),
+			"Unsupported Clickhouse Setting",
+			fmt.Sprintf("Setting %s is not known by the server, which runs Clickhouse %s", name.ValueString(), s.Version),
+		)
+	}
+	return diags
+}
+
+// Features returns whether each known feature is supported, keyed by feature key.
+func (s *ServerInfo) Features() map[string]bool {
+	features := map[string]bool{}
+	for _, feature := range serverFeatures {
+		features[feature.Key] = s.Supports(feature) == ""
+	}
+	return features
+}
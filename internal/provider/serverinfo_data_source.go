@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerInfoDataSource{}
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &ServerInfoDataSource{}
+}
+
+// ServerInfoDataSource exposes the server version and capabilities the provider checks resources against.
+type ServerInfoDataSource struct {
+	db clickhouse.Conn
+}
+
+type ServerInfoDataSourceModel struct {
+	Id           types.String            `tfsdk:"id"`
+	Version      types.String            `tfsdk:"version"`
+	VersionMajor types.Int64             `tfsdk:"version_major"`
+	VersionMinor types.Int64             `tfsdk:"version_minor"`
+	BuildOptions map[string]types.String `tfsdk:"build_options"`
+	Features     map[string]types.Bool   `tfsdk:"features"`
+}
+
+func (d *ServerInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+func (d *ServerInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to retrieve the version, build options and supported features of the Clickhouse server",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Server version as returned by version(), ie. 23.12.6.19",
+				Computed:            true,
+			},
+			"version_major": schema.Int64Attribute{
+				MarkdownDescription: "Major version, ie. 23",
+				Computed:            true,
+			},
+			"version_minor": schema.Int64Attribute{
+				MarkdownDescription: "Minor version, ie. 12",
+				Computed:            true,
+			},
+			"build_options": schema.MapAttribute{
+				MarkdownDescription: "Build options from system.build_options, ie. USE_AWS_S3 or USE_RDKAFKA",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"features": schema.MapAttribute{
+				MarkdownDescription: "Whether the server supports each feature the provider checks: s3queue, refreshable_materialized_view, " +
+					"named_collection_ddl, kafka, postgresql and sql_security",
+				ElementType: types.BoolType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ServerInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+func (d *ServerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := detectServer(ctx, d.db)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Server Info",
+			"Could not detect the server version, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Version = types.StringValue(info.Version)
+	data.VersionMajor = types.Int64Value(int64(info.Major))
+	data.VersionMinor = types.Int64Value(int64(info.Minor))
+	data.BuildOptions = map[string]types.String{}
+	for name, value := range info.BuildOptions {
+		data.BuildOptions[name] = types.StringValue(value)
+	}
+	data.Features = map[string]types.Bool{}
+	for key, supported := range info.Features() {
+		data.Features[key] = types.BoolValue(supported)
+	}
+	data.Id = data.Version
+
+	tflog.Trace(ctx, "read server info data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
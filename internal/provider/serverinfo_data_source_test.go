@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServerInfoDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServerInfoDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_server_info.current", "version_major", "23"),
+					resource.TestCheckResourceAttr("data.clickhouseops_server_info.current", "version_minor", "12"),
+					resource.TestCheckResourceAttr("data.clickhouseops_server_info.current", "features.s3queue", "true"),
+					resource.TestCheckResourceAttr("data.clickhouseops_server_info.current", "features.named_collection_ddl", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServerInfoDataSourceConfig = `
+data "clickhouseops_server_info" "current" {}
+`
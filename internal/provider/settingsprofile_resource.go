@@ -0,0 +1,520 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+var (
+	_ resource.Resource                   = &SettingsProfile{}
+	_ resource.ResourceWithConfigure      = &SettingsProfile{}
+	_ resource.ResourceWithImportState    = &SettingsProfile{}
+	_ resource.ResourceWithValidateConfig = &SettingsProfile{}
+	_ resource.ResourceWithModifyPlan     = &SettingsProfile{}
+)
+
+func NewSettingsProfile() resource.Resource {
+	return &SettingsProfile{}
+}
+
+type SettingsProfile struct {
+	db     clickhouse.Conn
+	server *ServerInfo
+}
+
+type SettingsProfileModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	ClusterName types.String   `tfsdk:"cluster_name"`
+	Settings    []SettingModel `tfsdk:"settings"`
+	Inherit     []types.String `tfsdk:"inherit"`
+	To          []types.String `tfsdk:"to"`
+	ToAll       types.Bool     `tfsdk:"to_all"`
+	ToExcept    []types.String `tfsdk:"to_except"`
+}
+
+type SettingModel struct {
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	Min         types.String `tfsdk:"min"`
+	Max         types.String `tfsdk:"max"`
+	Writability types.String `tfsdk:"writability"`
+}
+
+// settingsSchemaAttribute is shared by the access entities accepting SETTINGS constraints.
+func settingsSchemaAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Clickhouse setting name",
+					Required:            true,
+				},
+				"value": schema.StringAttribute{
+					MarkdownDescription: "Clickhouse setting value",
+					Optional:            true,
+				},
+				"min": schema.StringAttribute{
+					MarkdownDescription: "Minimum value allowed for the setting",
+					Optional:            true,
+				},
+				"max": schema.StringAttribute{
+					MarkdownDescription: "Maximum value allowed for the setting",
+					Optional:            true,
+				},
+				"writability": schema.StringAttribute{
+					MarkdownDescription: "One of `WRITABLE`, `CONST` (`READONLY` is an alias) or `CHANGEABLE_IN_READONLY`",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func (r *SettingsProfile) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings_profile"
+}
+
+func (r *SettingsProfile) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clickhouse settings profile",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse settings profile name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse cluster name",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"settings": settingsSchemaAttribute("Settings values and constraints of the profile"),
+			"inherit": schema.ListAttribute{
+				MarkdownDescription: "Settings profiles inherited by the profile",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"to": schema.SetAttribute{
+				MarkdownDescription: "Users or roles the profile is assigned to",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"to_all": schema.BoolAttribute{
+				MarkdownDescription: "Assign the profile to all users and roles, conflicts with `to`",
+				Optional:            true,
+			},
+			"to_except": schema.SetAttribute{
+				MarkdownDescription: "Users or roles excluded when `to_all` is true",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *SettingsProfile) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+	r.server = serverInfo(req.ProviderData)
+}
+
+// ModifyPlan fails the plan when a setting is not known by the server.
+func (r *SettingsProfile) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkSettings(ctx, req.Plan, false)...)
+}
+
+func (r *SettingsProfile) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SettingsProfileModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, setting := range data.Settings {
+		if err := validateWritability(setting.Writability); err != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtListIndex(i).AtName("writability"), "Invalid Attribute Configuration", err)
+		}
+	}
+
+	if data.ToAll.ValueBool() && len(data.To) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("to"), "Invalid Attribute Configuration", "Expect to to be empty when to_all is true")
+	}
+	if !data.ToAll.ValueBool() && len(data.ToExcept) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("to_except"), "Invalid Attribute Configuration", "Expect to_except to be defined only when to_all is true")
+	}
+}
+
+func validateWritability(writability types.String) string {
+	if writability.IsNull() || writability.IsUnknown() {
+		return ""
+	}
+	switch strings.ToUpper(writability.ValueString()) {
+	case "WRITABLE", "CONST", "READONLY", "CHANGEABLE_IN_READONLY":
+		return ""
+	}
+	return "Expect writability to be one of WRITABLE, CONST, READONLY or CHANGEABLE_IN_READONLY, got: " + writability.ValueString()
+}
+
+/*
+settingsElementsTemplate defines the "setting" template rendering a single SETTINGS element.
+
+	variable [= value] [MIN [=] min_value] [MAX [=] max_value] [CONST|READONLY|WRITABLE|CHANGEABLE_IN_READONLY]
+*/
+const settingsElementsTemplate = `
+{{define "setting"}}{{.Name.ValueString}}{{if not .Value.IsNull}} = '{{.Value.ValueString}}'{{end}}{{if not .Min.IsNull}} MIN '{{.Min.ValueString}}'{{end}}{{if not .Max.IsNull}} MAX '{{.Max.ValueString}}'{{end}}{{if not .Writability.IsNull}} {{.Writability.ValueString}}{{end}}{{end}}
+`
+
+/*
+	Clickhouse Settings Profile Syntax for reference
+
+CREATE SETTINGS PROFILE [IF NOT EXISTS | OR REPLACE] name1 [ON CLUSTER cluster_name1]
+
+	    [, name2 [ON CLUSTER cluster_name2] ...]
+	[IN access_storage_type]
+	[SETTINGS variable [= value] [MIN [=] min_value] [MAX [=] max_value] [CONST|READONLY|WRITABLE|CHANGEABLE_IN_READONLY] | INHERIT 'profile_name'] [,...]
+	[TO {{role1 | user1 [, role2 | user2 ...]} | NONE | ALL | ALL EXCEPT {role1 | user1 [, role2 | user2 ...]}}]
+*/
+const ddlCreateSettingsProfileTemplate = `
+{{if .Alter}}ALTER{{else}}CREATE{{end}} SETTINGS PROFILE "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{$size := size .Settings}}{{$size_inherit := size .Inherit}}
+{{if or .Settings .Inherit}}SETTINGS
+{{range $i, $e := .Inherit}}INHERIT '{{$e.ValueString}}'{{if or (lt $i $size_inherit) (gt $size -1)}},{{end}}
+{{end}}
+{{range $i, $e := .Settings}}{{template "setting" $e}}{{if lt $i $size}},{{end}}
+{{end}}
+{{else if .Alter}}SETTINGS NONE{{end}}
+{{$size_to := size .To}}{{$size_except := size .ToExcept}}
+{{if .ToAll.ValueBool}}TO ALL{{if .ToExcept}} EXCEPT {{range $i, $e := .ToExcept}}'{{$e.ValueString}}'{{if lt $i $size_except}},{{end}}{{end}}{{end}}
+{{- else if .To}}TO {{range $i, $e := .To}}'{{$e.ValueString}}'{{if lt $i $size_to}},{{end}}{{end}}{{else if .Alter}}TO NONE{{end}}
+` + settingsElementsTemplate
+
+/*
+DROP SETTINGS PROFILE [IF EXISTS] name [,...] [ON CLUSTER cluster_name] [FROM access_storage_type]
+.
+*/
+const ddlDropSettingsProfileTemplate = `
+DROP SETTINGS PROFILE IF EXISTS "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+`
+
+const readSettingsProfileQuery = `SELECT apply_to_all, apply_to_list, apply_to_except FROM system.settings_profiles WHERE name = ?`
+
+type SettingsProfileStatement struct {
+	*SettingsProfileModel
+	Alter bool
+}
+
+func (r *SettingsProfile) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SettingsProfileModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateSettingsProfileTemplate, SettingsProfileStatement{SettingsProfileModel: data})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Settings Profile",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Settings Profile",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.ClusterName.ValueString() + ":" + data.Name.ValueString())
+
+	tflog.Trace(ctx, "Created a Settings Profile Resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SettingsProfile) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SettingsProfileModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := r.db.Query(ctx, readSettingsProfileQuery, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Settings Profile",
+			"Could not read system.settings_profiles, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		tflog.Trace(ctx, "Settings profile not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var applyToAll uint8
+	var applyTo, applyToExcept []string
+	if err := rows.Scan(&applyToAll, &applyTo, &applyToExcept); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Settings Profile",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.To = stringValues(applyTo)
+	data.ToExcept = stringValues(applyToExcept)
+	if applyToAll == 1 || !data.ToAll.IsNull() {
+		data.ToAll = types.BoolValue(applyToAll == 1)
+	}
+
+	settings, inherit, err := readSettingsElements(ctx, r.db, "profile_name", data.Name.ValueString(), data.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Settings Profile",
+			"Could not read system.settings_profile_elements, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.Settings = settings
+	data.Inherit = inherit
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SettingsProfile) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SettingsProfileModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlCreateSettingsProfileTemplate, SettingsProfileStatement{SettingsProfileModel: data, Alter: true})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Settings Profile",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Settings Profile",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SettingsProfile) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SettingsProfileModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlDropSettingsProfileTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError("", ""+err.Error())
+		return
+	}
+}
+
+// ImportState accepts either the profile name or the resource ID in the form cluster_name:name.
+func (r *SettingsProfile) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByName(ctx, req, resp)
+}
+
+const readSettingsElementsQueryTemplate = `
+SELECT setting_name, value, min, max, CAST(writability, 'Nullable(String)'), inherit_profile
+FROM system.settings_profile_elements
+WHERE {{.}} = ?
+ORDER BY index
+`
+
+// readSettingsElements reads the SETTINGS of a profile, user or role from system.settings_profile_elements,
+// column is one of profile_name, user_name or role_name. Values and writability of the current settings are kept
+// when the server reports them in another spelling.
+func readSettingsElements(ctx context.Context, db clickhouse.Conn, column string, name string, current []SettingModel) ([]SettingModel, []types.String, error) {
+	query, err := common.RenderTemplate(readSettingsElementsQueryTemplate, column)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := db.Query(ctx, *query, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	configured := make(map[string]SettingModel, len(current))
+	for _, setting := range current {
+		configured[setting.Name.ValueString()] = setting
+	}
+
+	var settings []SettingModel
+	var profiles []types.String
+	for rows.Next() {
+		var settingName, value, minValue, maxValue, writability, inheritProfile *string
+		if err := rows.Scan(&settingName, &value, &minValue, &maxValue, &writability, &inheritProfile); err != nil {
+			return nil, nil, err
+		}
+		if inheritProfile != nil {
+			profiles = append(profiles, types.StringValue(*inheritProfile))
+		}
+		if settingName == nil {
+			continue
+		}
+
+		setting := SettingModel{
+			Name:        types.StringValue(*settingName),
+			Value:       types.StringPointerValue(value),
+			Min:         types.StringPointerValue(minValue),
+			Max:         types.StringPointerValue(maxValue),
+			Writability: types.StringPointerValue(writability),
+		}
+		if previous, ok := configured[*settingName]; ok {
+			if equalSettingValues(previous.Value, value) {
+				setting.Value = previous.Value
+			}
+			if equalSettingValues(previous.Min, minValue) {
+				setting.Min = previous.Min
+			}
+			if equalSettingValues(previous.Max, maxValue) {
+				setting.Max = previous.Max
+			}
+			if writability != nil && (strings.EqualFold(previous.Writability.ValueString(), *writability) ||
+				strings.EqualFold(previous.Writability.ValueString(), "READONLY") && *writability == "CONST") {
+				setting.Writability = previous.Writability
+			}
+		}
+		settings = append(settings, setting)
+	}
+	return settings, profiles, rows.Err()
+}
+
+// equalSettingValues compares a configured setting value with the one reported by the server,
+// ignoring case and the spelling of numbers, e.g. '10G' and 10000000000 or true and 1.
+func equalSettingValues(configured types.String, actual *string) bool {
+	if configured.IsNull() || actual == nil {
+		return false
+	}
+	left, right := strings.Trim(configured.ValueString(), "'"), strings.Trim(*actual, "'")
+	if strings.EqualFold(left, right) {
+		return true
+	}
+	leftNumber, ok := parseSettingNumber(left)
+	if !ok {
+		return false
+	}
+	rightNumber, ok := parseSettingNumber(right)
+	return ok && leftNumber == rightNumber
+}
+
+// settingSizeSuffixes are the size suffixes accepted by Clickhouse for numeric settings.
+var settingSizeSuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"K", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+func parseSettingNumber(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "true":
+		return 1, true
+	case "false":
+		return 0, true
+	}
+	multiplier := 1.0
+	for _, size := range settingSizeSuffixes {
+		if trimmed, found := strings.CutSuffix(value, size.suffix); found {
+			value, multiplier = strings.TrimSpace(trimmed), size.multiplier
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return number * multiplier, true
+}
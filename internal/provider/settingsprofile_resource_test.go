@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSettingsProfileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSettingsProfileResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_settings_profile.analysts", "settings.0.value", "10000000000"),
+					resource.TestCheckResourceAttr("clickhouseops_settings_profile.analysts", "to.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "clickhouseops_settings_profile.analysts",
+				ImportState:       true,
+				ImportStateId:     "analysts",
+				ImportStateVerify: true,
+			},
+			// Update in place
+			{
+				Config: testAccSettingsProfileResourceUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_settings_profile.analysts", "settings.0.value", "20G"),
+					resource.TestCheckResourceAttr("clickhouseops_settings_profile.analysts", "settings.0.writability", "const"),
+					resource.TestCheckResourceAttr("clickhouseops_settings_profile.analysts", "to_all", "true"),
+					resource.TestCheckResourceAttr("clickhouseops_settings_profile.analysts", "to_except.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccSettingsProfileResourceConfig = `
+resource "clickhouseops_simplerole" "analyst" {
+	name = "analyst"
+}
+
+resource "clickhouseops_settings_profile" "analysts" {
+	name = "analysts"
+	settings = [{
+		name = "max_memory_usage"
+		value = "10000000000"
+		min = "1000000"
+		max = "20000000000"
+	}]
+	inherit = ["default"]
+	to = [clickhouseops_simplerole.analyst.name]
+}
+`
+	testAccSettingsProfileResourceUpdatedConfig = `
+resource "clickhouseops_simplerole" "analyst" {
+	name = "analyst"
+}
+
+resource "clickhouseops_settings_profile" "analysts" {
+	name = "analysts"
+	settings = [{
+		name = "max_memory_usage"
+		value = "20G"
+		writability = "const"
+	}]
+	inherit = ["default"]
+	to_all = true
+	to_except = [clickhouseops_simplerole.analyst.name]
+}
+`
+)
@@ -19,9 +19,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &SimpleRole{}
-	_ resource.ResourceWithConfigure   = &SimpleRole{}
-	_ resource.ResourceWithImportState = &SimpleRole{}
+	_ resource.Resource                   = &SimpleRole{}
+	_ resource.ResourceWithConfigure      = &SimpleRole{}
+	_ resource.ResourceWithImportState    = &SimpleRole{}
+	_ resource.ResourceWithValidateConfig = &SimpleRole{}
+	_ resource.ResourceWithModifyPlan     = &SimpleRole{}
 )
 
 func NewSimpleRole() resource.Resource {
@@ -29,13 +31,16 @@ func NewSimpleRole() resource.Resource {
 }
 
 type SimpleRole struct {
-	db clickhouse.Conn
+	db     clickhouse.Conn
+	server *ServerInfo
 }
 
 type SimpleRoleModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	ClusterName types.String `tfsdk:"cluster_name"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	ClusterName types.String   `tfsdk:"cluster_name"`
+	Settings    []SettingModel `tfsdk:"settings"`
+	Profile     types.String   `tfsdk:"profile"`
 }
 
 func (r *SimpleRole) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,6 +72,11 @@ func (r *SimpleRole) Schema(ctx context.Context, req resource.SchemaRequest, res
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"settings": settingsSchemaAttribute("Settings and constraints applied to the role"),
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Settings profile applied to the role",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -88,6 +98,31 @@ func (r *SimpleRole) Configure(ctx context.Context, req resource.ConfigureReques
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+}
+
+// ModifyPlan fails the plan when a setting is not known by the server.
+func (r *SimpleRole) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkSettings(ctx, req.Plan, false)...)
+}
+
+func (r *SimpleRole) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SimpleRoleModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, setting := range data.Settings {
+		if err := validateWritability(setting.Writability); err != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtListIndex(i).AtName("writability"), "Invalid Attribute Configuration", err)
+		}
+	}
 }
 
 /*
@@ -99,8 +134,16 @@ CREATE ROLE [IF NOT EXISTS | OR REPLACE] name1 [ON CLUSTER cluster_name1] [, nam
 	[SETTINGS variable [= value] [MIN [=] min_value] [MAX [=] max_value] [CONST|READONLY|WRITABLE|CHANGEABLE_IN_READONLY] | PROFILE 'profile_name'] [,...]
 */
 const ddlSimpleRoleTemplate = `
-CREATE ROLE '{{.Name.ValueString}}'{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} 
-`
+{{if .Alter}}ALTER{{else}}CREATE{{end}} ROLE '{{.Name.ValueString}}'{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{$size := size .Settings}}{{if or .Settings (not .Profile.IsNull)}}SETTINGS {{if not .Profile.IsNull}}PROFILE '{{.Profile.ValueString}}'{{if .Settings}}, {{end}}{{end}}{{range $i, $e := .Settings}}{{template "setting" $e}}{{if lt $i $size}}, {{end}}{{end}}{{else if .Alter}}SETTINGS NONE{{end}}
+` + settingsElementsTemplate
+
+const readSimpleRoleQuery = `SELECT name FROM system.roles WHERE name = ?`
+
+type SimpleRoleStatement struct {
+	*SimpleRoleModel
+	Alter bool
+}
 
 func (r *SimpleRole) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *SimpleRoleModel
@@ -110,7 +153,7 @@ func (r *SimpleRole) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlSimpleRoleTemplate, data)
+	query, err := common.RenderTemplate(ddlSimpleRoleTemplate, SimpleRoleStatement{SimpleRoleModel: data})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse SimpleRole",
@@ -123,7 +166,7 @@ func (r *SimpleRole) Create(ctx context.Context, req resource.CreateRequest, res
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse SimpleRole",
-			"Could not execute DDL, unexpected error: "+err.Error(),
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
 		)
 		return
 	}
@@ -146,6 +189,37 @@ func (r *SimpleRole) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		return
 	}
 
+	rows, err := r.db.Query(ctx, readSimpleRoleQuery, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse SimpleRole",
+			"Could not read system.roles, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	found := rows.Next()
+	rows.Close()
+
+	if !found {
+		tflog.Trace(ctx, "Role not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	settings, profiles, err := readSettingsElements(ctx, r.db, "role_name", data.Name.ValueString(), data.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse SimpleRole",
+			"Could not read system.settings_profile_elements, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	data.Settings = settings
+	data.Profile = types.StringNull()
+	if len(profiles) > 0 {
+		data.Profile = profiles[0]
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -162,6 +236,24 @@ func (r *SimpleRole) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
+	query, err := common.RenderTemplate(ddlSimpleRoleTemplate, SimpleRoleStatement{SimpleRoleModel: data, Alter: true})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse SimpleRole",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse SimpleRole",
+			"Could not execute DDL: "+*query+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -189,6 +281,7 @@ func (r *SimpleRole) Delete(ctx context.Context, req resource.DeleteRequest, res
 	}
 }
 
+// ImportState accepts either the role name or the resource ID in the form cluster_name:name.
 func (r *SimpleRole) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByName(ctx, req, resp)
 }
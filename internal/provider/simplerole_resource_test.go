@@ -21,12 +21,41 @@ func TestAccSimpleRoleResource(t *testing.T) {
 					resource.TestCheckResourceAttr("clickhouseops_simplerole.new_role", "name", "new_role"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:      "clickhouseops_simplerole.new_role",
+				ImportState:       true,
+				ImportStateId:     "new_role",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccSimpleRoleUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_simplerole.new_role", "profile", "default"),
+					resource.TestCheckResourceAttr("clickhouseops_simplerole.new_role", "settings.#", "1"),
+					resource.TestCheckResourceAttr("clickhouseops_simplerole.new_role", "settings.0.max", "100000000000"),
+				),
+			},
 		},
 	})
 }
 
-const testAccSimpleRoleConfig = `
+const (
+	testAccSimpleRoleConfig = `
 resource "clickhouseops_simplerole" "new_role" {
   name = "new_role"
 }
 `
+	testAccSimpleRoleUpdatedConfig = `
+resource "clickhouseops_simplerole" "new_role" {
+  name    = "new_role"
+  profile = "default"
+  settings = [{
+    name  = "max_memory_usage"
+    value = "50000000000"
+    max   = "100000000000"
+  }]
+}
+`
+)
@@ -5,9 +5,16 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,9 +26,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &SimpleUser{}
-	_ resource.ResourceWithConfigure   = &SimpleUser{}
-	_ resource.ResourceWithImportState = &SimpleUser{}
+	_ resource.Resource                   = &SimpleUser{}
+	_ resource.ResourceWithConfigure      = &SimpleUser{}
+	_ resource.ResourceWithImportState    = &SimpleUser{}
+	_ resource.ResourceWithValidateConfig = &SimpleUser{}
+	_ resource.ResourceWithModifyPlan     = &SimpleUser{}
 )
 
 func NewSimpleUser() resource.Resource {
@@ -29,17 +38,117 @@ func NewSimpleUser() resource.Resource {
 }
 
 type SimpleUser struct {
-	db clickhouse.Conn
+	db      clickhouse.Conn
+	server  *ServerInfo
+	secrets *Secrets
 }
 
 type SimpleUserModel struct {
-	ID                  types.String `tfsdk:"id"`
-	Name                types.String `tfsdk:"name"`
-	ClusterName         types.String `tfsdk:"cluster_name"`
-	SHA256Password      types.String `tfsdk:"sha256_password"`
-	ValidDatetime       types.String `tfsdk:"valid_datetime"`
-	DefaultRoleName     types.String `tfsdk:"default_role_name"`
-	DefaultDatabaseName types.String `tfsdk:"default_database_name"`
+	ID                       types.String                `tfsdk:"id"`
+	Name                     types.String                `tfsdk:"name"`
+	ClusterName              types.String                `tfsdk:"cluster_name"`
+	SHA256Password           types.String                `tfsdk:"sha256_password"`
+	SHA256PasswordSecret     types.String                `tfsdk:"sha256_password_secret"`
+	SHA256PasswordSecretHash types.String                `tfsdk:"sha256_password_secret_hash"`
+	Authentication           *UserAuthenticationModel    `tfsdk:"authentication"`
+	GeneratePassword         *UserPasswordGeneratorModel `tfsdk:"generate_password"`
+	Password                 types.String                `tfsdk:"password"`
+	ValidDatetime            types.String                `tfsdk:"valid_datetime"`
+	DefaultRoleName          types.String                `tfsdk:"default_role_name"`
+	DefaultDatabaseName      types.String                `tfsdk:"default_database_name"`
+	Hosts                    []UserHostModel             `tfsdk:"hosts"`
+	Grantees                 []types.String              `tfsdk:"grantees"`
+	Settings                 []SettingModel              `tfsdk:"settings"`
+	Profile                  types.String                `tfsdk:"profile"`
+}
+
+type UserHostModel struct {
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+type UserAuthenticationModel struct {
+	Method      types.String      `tfsdk:"method"`
+	Hash        types.String      `tfsdk:"hash"`
+	Server      types.String      `tfsdk:"server"`
+	Realm       types.String      `tfsdk:"realm"`
+	CommonNames []types.String    `tfsdk:"common_names"`
+	SSHKeys     []UserSSHKeyModel `tfsdk:"ssh_keys"`
+}
+
+type UserPasswordGeneratorModel struct {
+	Length  types.Int64  `tfsdk:"length"`
+	Charset types.String `tfsdk:"charset"`
+}
+
+// defaultPasswordCharset leaves out quotes and backslashes so the password can be used in any client configuration.
+const defaultPasswordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#%*+-_=.,:"
+
+const defaultPasswordLength = 32
+
+// PasswordHash returns the hex encoded SHA256 hash of the generated password.
+func (m SimpleUserModel) PasswordHash() string {
+	hash := sha256.Sum256([]byte(m.Password.ValueString()))
+	return hex.EncodeToString(hash[:])
+}
+
+// generatePassword returns a random password drawn uniformly from the generator charset.
+func generatePassword(generator *UserPasswordGeneratorModel) (string, error) {
+	length := int64(defaultPasswordLength)
+	if !generator.Length.IsNull() {
+		length = generator.Length.ValueInt64()
+	}
+	charset := []rune(defaultPasswordCharset)
+	if !generator.Charset.IsNull() {
+		charset = []rune(generator.Charset.ValueString())
+	}
+
+	password := make([]rune, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = charset[n.Int64()]
+	}
+	return string(password), nil
+}
+
+// generatedPasswordModifier keeps the generated password until the generator settings change.
+type generatedPasswordModifier struct{}
+
+func (m generatedPasswordModifier) Description(ctx context.Context) string {
+	return "Keeps the generated password unless generate_password changes."
+}
+
+func (m generatedPasswordModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m generatedPasswordModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("generate_password"), &plan)...)
+	if plan.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("generate_password"), &state)...)
+	if plan.Equal(state) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+type UserSSHKeyModel struct {
+	Key  types.String `tfsdk:"key"`
+	Type types.String `tfsdk:"type"`
 }
 
 func (r *SimpleUser) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -72,34 +181,122 @@ func (r *SimpleUser) Schema(ctx context.Context, req resource.SchemaRequest, res
 				},
 			},
 			"sha256_password": schema.StringAttribute{
-				MarkdownDescription: "SHA256 hash with the user password",
-				Required:            true,
+				MarkdownDescription: "SHA256 hash with the user password, stored in state. Conflicts with sha256_password_secret and authentication",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"sha256_password_secret": schema.StringAttribute{
+				MarkdownDescription: "Name of the provider secret holding the SHA256 hash of the user password, which is not stored in state. Conflicts with sha256_password and authentication",
+				Optional:            true,
+			},
+			"sha256_password_secret_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the hash read from sha256_password_secret, a rotated secret alters the user",
+				Computed:            true,
+			},
+			"generate_password": schema.SingleNestedAttribute{
+				MarkdownDescription: "Let the provider generate a random password, only its SHA256 hash is sent to Clickhouse. Changing it generates a new password. Conflicts with sha256_password and authentication",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"length": schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("Password length, defaults to %d", defaultPasswordLength),
+						Optional:            true,
+					},
+					"charset": schema.StringAttribute{
+						MarkdownDescription: "Characters the password is made of, defaults to letters, digits and `" + defaultPasswordCharset[62:] + "`",
+						Optional:            true,
+					},
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Generated password, set when generate_password is used",
+				Computed:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					generatedPasswordModifier{},
+				},
+			},
+			"authentication": schema.SingleNestedAttribute{
+				MarkdownDescription: "User authentication method, conflicts with sha256_password",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"method": schema.StringAttribute{
+						MarkdownDescription: "One of sha256_hash, double_sha1_hash, bcrypt_hash, ldap, kerberos, ssl_certificate, ssh_key or no_password",
+						Required:            true,
+					},
+					"hash": schema.StringAttribute{
+						MarkdownDescription: "Password hash for sha256_hash, double_sha1_hash and bcrypt_hash",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"server": schema.StringAttribute{
+						MarkdownDescription: "LDAP server name as defined in the server configuration, for ldap",
+						Optional:            true,
+					},
+					"realm": schema.StringAttribute{
+						MarkdownDescription: "Kerberos realm the user must belong to, for kerberos",
+						Optional:            true,
+					},
+					"common_names": schema.ListAttribute{
+						MarkdownDescription: "Allowed certificate common names, for ssl_certificate",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"ssh_keys": schema.ListNestedAttribute{
+						MarkdownDescription: "Allowed public keys, for ssh_key",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"key": schema.StringAttribute{
+									MarkdownDescription: "Base64 encoded public key",
+									Required:            true,
+								},
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Key type, e.g. ssh-rsa or ssh-ed25519",
+									Required:            true,
+								},
+							},
+						},
+					},
 				},
 			},
 			"valid_datetime": schema.StringAttribute{
 				MarkdownDescription: "String expression containing date with optional time limit for the user to be valid",
 				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"default_role_name": schema.StringAttribute{
 				MarkdownDescription: "default Clickhouse role for the user",
 				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"default_database_name": schema.StringAttribute{
 				MarkdownDescription: "default Clickhouse database for the user",
 				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			},
+			"hosts": schema.ListNestedAttribute{
+				MarkdownDescription: "Hosts the user is allowed to connect from, any host when omitted",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "One of IP, NAME, REGEXP, LIKE or LOCAL",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "IP address or subnet, host name, regular expression or LIKE pattern, not used with LOCAL",
+							Optional:            true,
+						},
+					},
 				},
 			},
+			"grantees": schema.ListAttribute{
+				MarkdownDescription: "Users or roles allowed to receive privileges from this user, ANY or NONE are accepted too. Any when omitted",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"settings": settingsSchemaAttribute("Settings and constraints applied to the user"),
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Settings profile applied to the user",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -121,6 +318,122 @@ func (r *SimpleUser) Configure(ctx context.Context, req resource.ConfigureReques
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+	r.secrets = providerSecrets(req.ProviderData)
+}
+
+// ModifyPlan fails the plan when a setting is not known by the server, and plans the hash of the password secret.
+func (r *SimpleUser) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.checkSettings(ctx, req.Plan, false)...)
+	r.secrets.planSecretHash(ctx, req, resp, "sha256_password_secret", "sha256_password_secret_hash")
+}
+
+func (r *SimpleUser) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SimpleUserModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identifications := 0
+	for _, set := range []bool{!data.SHA256Password.IsNull(), !data.SHA256PasswordSecret.IsNull(), data.Authentication != nil, data.GeneratePassword != nil} {
+		if set {
+			identifications++
+		}
+	}
+	if identifications != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("authentication"),
+			"Invalid Attribute Configuration",
+			"Expect exactly one of sha256_password, sha256_password_secret, authentication or generate_password to be set",
+		)
+	}
+	if generator := data.GeneratePassword; generator != nil {
+		if !generator.Length.IsNull() && !generator.Length.IsUnknown() && generator.Length.ValueInt64() < 8 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("generate_password").AtName("length"),
+				"Invalid Attribute Configuration",
+				fmt.Sprintf("Expect length to be at least 8, got: %d", generator.Length.ValueInt64()),
+			)
+		}
+		if !generator.Charset.IsNull() && !generator.Charset.IsUnknown() && len([]rune(generator.Charset.ValueString())) < 2 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("generate_password").AtName("charset"),
+				"Invalid Attribute Configuration",
+				"Expect charset to contain at least 2 characters",
+			)
+		}
+	}
+	for i, host := range data.Hosts {
+		switch strings.ToUpper(host.Type.ValueString()) {
+		case "LOCAL":
+		case "IP", "NAME", "REGEXP", "LIKE":
+			if host.Value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("hosts").AtListIndex(i).AtName("value"),
+					"Invalid Attribute Configuration",
+					"Expect value to be set for host type "+host.Type.ValueString(),
+				)
+			}
+		default:
+			if !host.Type.IsUnknown() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("hosts").AtListIndex(i).AtName("type"),
+					"Invalid Attribute Configuration",
+					"Expect type to be one of IP, NAME, REGEXP, LIKE or LOCAL, got: "+host.Type.ValueString(),
+				)
+			}
+		}
+	}
+	for i, setting := range data.Settings {
+		if err := validateWritability(setting.Writability); err != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtListIndex(i).AtName("writability"), "Invalid Attribute Configuration", err)
+		}
+	}
+
+	if data.Authentication == nil || data.Authentication.Method.IsUnknown() {
+		return
+	}
+
+	authentication := data.Authentication
+	var missing string
+	switch authentication.Method.ValueString() {
+	case "sha256_hash", "double_sha1_hash", "bcrypt_hash":
+		if authentication.Hash.IsNull() {
+			missing = "hash"
+		}
+	case "ldap":
+		if authentication.Server.IsNull() {
+			missing = "server"
+		}
+	case "ssl_certificate":
+		if len(authentication.CommonNames) == 0 {
+			missing = "common_names"
+		}
+	case "ssh_key":
+		if len(authentication.SSHKeys) == 0 {
+			missing = "ssh_keys"
+		}
+	case "kerberos", "no_password":
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("authentication").AtName("method"),
+			"Invalid Attribute Configuration",
+			"Expect method to be one of sha256_hash, double_sha1_hash, bcrypt_hash, ldap, kerberos, ssl_certificate, ssh_key or no_password, got: "+authentication.Method.ValueString(),
+		)
+	}
+	if missing != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("authentication").AtName(missing),
+			"Invalid Attribute Configuration",
+			"Expect "+missing+" to be set for authentication method "+authentication.Method.ValueString(),
+		)
+	}
 }
 
 /*
@@ -139,11 +452,79 @@ CREATE USER [IF NOT EXISTS | OR REPLACE] name1 [ON CLUSTER cluster_name1]
 	[SETTINGS variable [= value] [MIN [=] min_value] [MAX [=] max_value] [READONLY | WRITABLE] | PROFILE 'profile_name'] [,...]
 */
 const ddlSimpleUserTemplate = `
-CREATE USER "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} 
-IDENTIFIED WITH sha256_hash BY '{{.SHA256Password.ValueString}}'
-{{if not .ValidDatetime.IsNull}}VALID UNTIL '{{.ValidDatetime.ValueInt64}}'{{end}}
-{{if not .DefaultRoleName.IsNull}}DEFAULT ROLE '{{.DefaultRoleName.ValueString}}'{{end}}
-{{if not .DefaultDatabaseName.IsNull}}DEFAULT DATABASE '{{.DefaultDatabaseName.ValueString}}'{{end}}
+{{if .Alter}}ALTER{{else}}CREATE{{end}} USER "{{.Name.ValueString}}"{{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{if .Changed.identification}}{{if not .SHA256Password.IsNull}}IDENTIFIED WITH sha256_hash BY '{{.SHA256Password.ValueString}}'{{else if not .Password.IsNull}}IDENTIFIED WITH sha256_hash BY '{{.PasswordHash}}'{{else}}{{template "authentication" .Authentication}}{{end}}{{end}}
+{{if .Changed.hosts}}{{$size_hosts := size .Hosts}}{{if .Hosts}}HOST {{range $i, $e := .Hosts}}{{$e.Type.ValueString}}{{if not $e.Value.IsNull}} '{{$e.Value.ValueString}}'{{end}}{{if lt $i $size_hosts}}, {{end}}{{end}}{{else if .Alter}}HOST ANY{{end}}{{end}}
+{{if .Changed.valid_until}}{{if not .ValidDatetime.IsNull}}VALID UNTIL '{{.ValidDatetime.ValueString}}'{{else if .Alter}}VALID UNTIL 'infinity'{{end}}{{end}}
+{{if .Changed.default_role}}{{if not .DefaultRoleName.IsNull}}DEFAULT ROLE '{{.DefaultRoleName.ValueString}}'{{else if .Alter}}DEFAULT ROLE NONE{{end}}{{end}}
+{{if .Changed.default_database}}{{if not .DefaultDatabaseName.IsNull}}DEFAULT DATABASE "{{.DefaultDatabaseName.ValueString}}"{{else if .Alter}}DEFAULT DATABASE NONE{{end}}{{end}}
+{{if .Changed.grantees}}{{$size_grantees := size .Grantees}}{{if .Grantees}}GRANTEES {{range $i, $e := .Grantees}}{{if or (eq $e.ValueString "ANY") (eq $e.ValueString "NONE")}}{{$e.ValueString}}{{else}}'{{$e.ValueString}}'{{end}}{{if lt $i $size_grantees}}, {{end}}{{end}}{{else if .Alter}}GRANTEES ANY{{end}}{{end}}
+{{if .Changed.settings}}{{$size := size .Settings}}{{if or .Settings (not .Profile.IsNull)}}SETTINGS {{if not .Profile.IsNull}}PROFILE '{{.Profile.ValueString}}'{{if .Settings}}, {{end}}{{end}}{{range $i, $e := .Settings}}{{template "setting" $e}}{{if lt $i $size}}, {{end}}{{end}}{{else if .Alter}}SETTINGS NONE{{end}}{{end}}
+` + ddlUserAuthenticationTemplate + settingsElementsTemplate
+
+// setGeneratedPassword generates the password when planned as unknown, it is null without generate_password.
+func setGeneratedPassword(data *SimpleUserModel) error {
+	if data.GeneratePassword == nil {
+		data.Password = types.StringNull()
+		return nil
+	}
+	if !data.Password.IsUnknown() {
+		return nil
+	}
+
+	password, err := generatePassword(data.GeneratePassword)
+	if err != nil {
+		return err
+	}
+	data.Password = types.StringValue(password)
+	return nil
+}
+
+// resolvePassword sets the hash of the password secret on data and returns a copy to render, where
+// sha256_password holds the secret. The secret itself never reaches the model written to state.
+func (r *SimpleUser) resolvePassword(data *SimpleUserModel) (*SimpleUserModel, diag.Diagnostics) {
+	password, diags := r.secrets.Resolve(path.Root("sha256_password_secret"), data.SHA256PasswordSecret)
+	data.SHA256PasswordSecretHash = secretHash(password)
+
+	statement := *data
+	if !password.IsNull() {
+		statement.SHA256Password = password
+	}
+	return &statement, diags
+}
+
+type SimpleUserStatement struct {
+	*SimpleUserModel
+	Alter bool
+	// Changed holds the clauses to render, all of them on CREATE.
+	Changed map[string]bool
+}
+
+// simpleUserChanges returns the clauses which differ between the state and the plan, every clause without state.
+func simpleUserChanges(state, data *SimpleUserModel) map[string]bool {
+	if state == nil {
+		state = &SimpleUserModel{}
+	}
+	return map[string]bool{
+		"identification": !state.SHA256Password.Equal(data.SHA256Password) || !state.SHA256PasswordSecretHash.Equal(data.SHA256PasswordSecretHash) ||
+			!reflect.DeepEqual(state.Authentication, data.Authentication) || !state.Password.Equal(data.Password),
+		"hosts":            !reflect.DeepEqual(state.Hosts, data.Hosts),
+		"valid_until":      !state.ValidDatetime.Equal(data.ValidDatetime),
+		"default_role":     !state.DefaultRoleName.Equal(data.DefaultRoleName),
+		"default_database": !state.DefaultDatabaseName.Equal(data.DefaultDatabaseName),
+		"grantees":         !reflect.DeepEqual(state.Grantees, data.Grantees),
+		"settings":         !reflect.DeepEqual(state.Settings, data.Settings) || !state.Profile.Equal(data.Profile),
+	}
+}
+
+const ddlUserAuthenticationTemplate = `
+{{define "authentication"}}IDENTIFIED WITH {{.Method.ValueString}}
+{{- if not .Hash.IsNull}} BY '{{.Hash.ValueString}}'{{end}}
+{{- if not .Server.IsNull}} SERVER '{{.Server.ValueString}}'{{end}}
+{{- if not .Realm.IsNull}} REALM '{{.Realm.ValueString}}'{{end}}
+{{- with .CommonNames}}{{$size := size .}} CN {{range $i, $e := .}}'{{$e.ValueString}}'{{if lt $i $size}}, {{end}}{{end}}{{end}}
+{{- with .SSHKeys}}{{$size := size .}} BY {{range $i, $e := .}}KEY '{{$e.Key.ValueString}}' TYPE '{{$e.Type.ValueString}}'{{if lt $i $size}}, {{end}}{{end}}{{end}}
+{{- end}}
 `
 
 func (r *SimpleUser) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -154,7 +535,21 @@ func (r *SimpleUser) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
-	query, err := common.RenderTemplate(ddlSimpleUserTemplate, data)
+	if err := setGeneratedPassword(data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Clickhouse Simple User",
+			"Could not generate password, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	statement, diags := r.resolvePassword(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := common.RenderTemplate(ddlSimpleUserTemplate, SimpleUserStatement{SimpleUserModel: statement, Changed: simpleUserChanges(nil, data)})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse Simple User",
@@ -197,15 +592,50 @@ func (r *SimpleUser) Read(ctx context.Context, req resource.ReadRequest, resp *r
 }
 
 func (r *SimpleUser) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *SimpleUserModel
+	var data, state *SimpleUserModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if err := setGeneratedPassword(data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Simple User",
+			"Could not generate password, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resolved, diags := r.resolvePassword(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ALTER USER keeps grants and role assignments, unlike dropping and creating the user again.
+	statement := SimpleUserStatement{SimpleUserModel: resolved, Alter: true, Changed: simpleUserChanges(state, data)}
+	query, err := common.RenderTemplate(ddlSimpleUserTemplate, statement)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Simple User",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse Simple User",
+			"Could not execute DDL, unexpected error: "+*query+err.Error(),
+		)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -4,11 +4,50 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+func TestAccSimpleUserAuthenticationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSimpleUserAuthenticationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_simpleuser.service", "authentication.method", "ssl_certificate"),
+					resource.TestCheckResourceAttr("clickhouseops_simpleuser.bcrypt", "authentication.method", "bcrypt_hash"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSimpleUserGeneratedPasswordResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSimpleUserGeneratedPasswordConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("clickhouseops_simpleuser.generated", "password", func(value string) error {
+						if len(value) != 24 {
+							return fmt.Errorf("expected a 24 characters password, got %d", len(value))
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSimpleUserResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -21,13 +60,68 @@ func TestAccSimpleUserResource(t *testing.T) {
 					resource.TestCheckResourceAttr("clickhouseops_simpleuser.new_user", "name", "new_user"),
 				),
 			},
+			// Update in place
+			{
+				Config: testAccSimpleUserUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_simpleuser.new_user", "valid_datetime", "2099-01-01 00:00:00"),
+					resource.TestCheckResourceAttr("clickhouseops_simpleuser.new_user", "hosts.#", "2"),
+					resource.TestCheckResourceAttr("clickhouseops_simpleuser.new_user", "settings.0.name", "max_threads"),
+				),
+			},
 		},
 	})
 }
 
-const testAccSimpleUserConfig = `
+const (
+	testAccSimpleUserConfig = `
 resource "clickhouseops_simpleuser" "new_user" {
   name = "new_user"
   sha256_password = sha256("dummy_password")
 }
 `
+	testAccSimpleUserUpdatedConfig = `
+resource "clickhouseops_simpleuser" "new_user" {
+  name = "new_user"
+  sha256_password = sha256("rotated_password")
+  valid_datetime = "2099-01-01 00:00:00"
+  hosts = [{
+    type = "IP"
+    value = "10.0.0.0/8"
+  }, {
+    type = "LOCAL"
+  }]
+  grantees = ["NONE"]
+  settings = [{
+    name = "max_threads"
+    value = "4"
+  }]
+  profile = "default"
+}
+`
+	testAccSimpleUserGeneratedPasswordConfig = `
+resource "clickhouseops_simpleuser" "generated" {
+  name = "generated"
+  generate_password = {
+    length = 24
+  }
+}
+`
+	testAccSimpleUserAuthenticationConfig = `
+resource "clickhouseops_simpleuser" "service" {
+  name = "service"
+  authentication = {
+    method = "ssl_certificate"
+    common_names = ["service.mesh.local"]
+  }
+}
+
+resource "clickhouseops_simpleuser" "bcrypt" {
+  name = "bcrypt"
+  authentication = {
+    method = "bcrypt_hash"
+    hash = "$2a$12$RwZHsTbjjSXwrqKmvTxLxO5VcT2wTIOP0Ll6q0o6RhCXs8rbr9hKK"
+  }
+}
+`
+)
@@ -0,0 +1,327 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/katzucurry/terraform-provider-clickhouseops/internal/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TableSchemaDataSource{}
+
+func NewTableSchemaDataSource() datasource.DataSource {
+	return &TableSchemaDataSource{}
+}
+
+// TableSchemaDataSource describes a table that already exists in Clickhouse.
+type TableSchemaDataSource struct {
+	db clickhouse.Conn
+}
+
+type TableSchemaDataSourceModel struct {
+	Id           types.String            `tfsdk:"id"`
+	DatabaseName types.String            `tfsdk:"database_name"`
+	TableName    types.String            `tfsdk:"table_name"`
+	Engine       types.String            `tfsdk:"engine"`
+	EngineFull   types.String            `tfsdk:"engine_full"`
+	PartitionKey types.String            `tfsdk:"partition_key"`
+	SortingKey   types.String            `tfsdk:"sorting_key"`
+	PrimaryKey   types.String            `tfsdk:"primary_key"`
+	SamplingKey  types.String            `tfsdk:"sampling_key"`
+	Comment      types.String            `tfsdk:"comment"`
+	Columns      []TableColumnDataModel  `tfsdk:"columns"`
+	Settings     []TableSettingDataModel `tfsdk:"settings"`
+}
+
+type TableColumnDataModel struct {
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	DefaultKind       types.String `tfsdk:"default_kind"`
+	DefaultExpression types.String `tfsdk:"default_expression"`
+	Codec             types.String `tfsdk:"codec"`
+	TTL               types.String `tfsdk:"ttl"`
+	Comment           types.String `tfsdk:"comment"`
+}
+
+type TableSettingDataModel struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (d *TableSchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_schema"
+}
+
+func (d *TableSchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to retrieve the columns, engine, keys and settings of an existing table from system.tables and DESCRIBE TABLE",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Database of the table",
+				Required:            true,
+			},
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Table name",
+				Required:            true,
+			},
+			"engine": schema.StringAttribute{
+				MarkdownDescription: "Table engine, ie. ReplicatedMergeTree",
+				Computed:            true,
+			},
+			"engine_full": schema.StringAttribute{
+				MarkdownDescription: "Engine with its parameters, keys and settings as written in the table definition",
+				Computed:            true,
+			},
+			"partition_key": schema.StringAttribute{
+				MarkdownDescription: "PARTITION BY expression, null when not set",
+				Computed:            true,
+			},
+			"sorting_key": schema.StringAttribute{
+				MarkdownDescription: "ORDER BY expression, null when not set",
+				Computed:            true,
+			},
+			"primary_key": schema.StringAttribute{
+				MarkdownDescription: "PRIMARY KEY expression, null when not set",
+				Computed:            true,
+			},
+			"sampling_key": schema.StringAttribute{
+				MarkdownDescription: "SAMPLE BY expression, null when not set",
+				Computed:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Table comment, null when not set",
+				Computed:            true,
+			},
+			"columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Table columns in definition order",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Column name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Clickhouse type",
+							Computed:            true,
+						},
+						"default_kind": schema.StringAttribute{
+							MarkdownDescription: "DEFAULT, MATERIALIZED, ALIAS or EPHEMERAL, null when the column has no default",
+							Computed:            true,
+						},
+						"default_expression": schema.StringAttribute{
+							MarkdownDescription: "Default expression, null when the column has no default",
+							Computed:            true,
+						},
+						"codec": schema.StringAttribute{
+							MarkdownDescription: "Compression codec, ie. CODEC(ZSTD(1)), null when not set",
+							Computed:            true,
+						},
+						"ttl": schema.StringAttribute{
+							MarkdownDescription: "Column TTL expression, null when not set",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Column comment, null when not set",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"settings": schema.ListNestedAttribute{
+				MarkdownDescription: "Table settings found in the SETTINGS clause of engine_full",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Setting name",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Setting value, unquoted",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TableSchemaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readTableSchemaQuery = `
+SELECT engine, engine_full, partition_key, sorting_key, primary_key, sampling_key, comment
+FROM system.tables
+WHERE database = ? AND name = ?
+`
+
+const describeTableTemplate = `DESCRIBE TABLE "{{.DatabaseName.ValueString}}"."{{.TableName.ValueString}}"`
+
+func (d *TableSchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TableSchemaDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := d.db.Query(ctx, readTableSchemaQuery, data.DatabaseName.ValueString(), data.TableName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Table Schema",
+			"Could not read system.tables, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Table Schema",
+			"Could not find table "+data.DatabaseName.ValueString()+"."+data.TableName.ValueString(),
+		)
+		return
+	}
+
+	var engine, engineFull, partitionKey, sortingKey, primaryKey, samplingKey, comment string
+	if err := rows.Scan(&engine, &engineFull, &partitionKey, &sortingKey, &primaryKey, &samplingKey, &comment); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Table Schema",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+	rows.Close()
+
+	data.Engine = types.StringValue(engine)
+	data.EngineFull = types.StringValue(engineFull)
+	data.PartitionKey = stringOrNull(partitionKey)
+	data.SortingKey = stringOrNull(sortingKey)
+	data.PrimaryKey = stringOrNull(primaryKey)
+	data.SamplingKey = stringOrNull(samplingKey)
+	data.Comment = stringOrNull(comment)
+	data.Settings = engineSettings(engineFull)
+
+	query, err := common.RenderTemplate(describeTableTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Table Schema",
+			"Could not render DESCRIBE, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	described, err := describeColumns(ctx, d.db, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Table Schema",
+			"Could not execute DESCRIBE: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Columns = nil
+	for _, col := range described {
+		data.Columns = append(data.Columns, TableColumnDataModel{
+			Name:              types.StringValue(col.Name),
+			Type:              types.StringValue(col.Type),
+			DefaultKind:       stringOrNull(col.DefaultType),
+			DefaultExpression: stringOrNull(col.DefaultExpression),
+			Codec:             stringOrNull(col.CodecExpression),
+			TTL:               stringOrNull(col.TTLExpression),
+			Comment:           stringOrNull(col.Comment),
+		})
+	}
+
+	data.Id = types.StringValue(data.DatabaseName.ValueString() + "." + data.TableName.ValueString())
+
+	tflog.Trace(ctx, "read table schema data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// stringOrNull maps the empty strings Clickhouse reports for unset fields to null.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// engineSettings parses the SETTINGS clause ending engine_full, quoted values are unquoted.
+func engineSettings(engineFull string) []TableSettingDataModel {
+	index := strings.LastIndex(engineFull, " SETTINGS ")
+	if index < 0 {
+		return nil
+	}
+	clause := engineFull[index+len(" SETTINGS "):]
+
+	var settings []TableSettingDataModel
+	var current strings.Builder
+	quoted := false
+	flush := func() {
+		name, value, found := strings.Cut(current.String(), "=")
+		current.Reset()
+		if !found {
+			return
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = strings.NewReplacer(`\'`, `'`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		}
+		settings = append(settings, TableSettingDataModel{
+			Name:  types.StringValue(strings.TrimSpace(name)),
+			Value: types.StringValue(value),
+		})
+	}
+	for i := 0; i < len(clause); i++ {
+		switch c := clause[i]; {
+		case c == '\\' && quoted && i+1 < len(clause):
+			current.WriteByte(c)
+			i++
+			current.WriteByte(clause[i])
+		case c == '\'':
+			quoted = !quoted
+			current.WriteByte(c)
+		case c == ',' && !quoted:
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return settings
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTableSchemaDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTableSchemaDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "engine", "MergeTree"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "sorting_key", "a"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "partition_key", "b"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "columns.#", "2"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "columns.0.name", "a"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "columns.1.type", "UInt32"),
+					resource.TestCheckNoResourceAttr("data.clickhouseops_table_schema.events", "columns.0.default_kind"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "settings.0.name", "index_granularity"),
+					resource.TestCheckResourceAttr("data.clickhouseops_table_schema.events", "settings.0.value", "4096"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTableSchemaDataSourceConfig = `
+resource "clickhouseops_database" "schema_database" {
+  name = "schema_db"
+}
+
+resource "clickhouseops_mergetree" "events" {
+  name = "events"
+  database_name = clickhouseops_database.schema_database.name
+  columns = [{
+    name = "a"
+    type = "String"
+  },{
+    name = "b"
+    type = "UInt32"
+  }]
+  order_by = ["a"]
+  partition_by = "b"
+  settings = [{
+    name = "index_granularity"
+    value = "4096"
+  }]
+}
+
+data "clickhouseops_table_schema" "events" {
+  database_name = clickhouseops_mergetree.events.database_name
+  table_name = clickhouseops_mergetree.events.name
+}
+`
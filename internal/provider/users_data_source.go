@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource lists the users found in system.users.
+type UsersDataSource struct {
+	db clickhouse.Conn
+}
+
+type UsersDataSourceModel struct {
+	Id      types.String    `tfsdk:"id"`
+	Name    types.String    `tfsdk:"name"`
+	Pattern types.String    `tfsdk:"pattern"`
+	Users   []UserDataModel `tfsdk:"users"`
+}
+
+type UserDataModel struct {
+	Name            types.String   `tfsdk:"name"`
+	Id              types.String   `tfsdk:"id"`
+	Storage         types.String   `tfsdk:"storage"`
+	AuthType        types.String   `tfsdk:"auth_type"`
+	HostIP          []types.String `tfsdk:"host_ip"`
+	HostNames       []types.String `tfsdk:"host_names"`
+	DefaultRolesAll types.Bool     `tfsdk:"default_roles_all"`
+	DefaultRoles    []types.String `tfsdk:"default_roles"`
+	DefaultDatabase types.String   `tfsdk:"default_database"`
+	GranteesAny     types.Bool     `tfsdk:"grantees_any"`
+	Grantees        []types.String `tfsdk:"grantees"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source to look up users from system.users, including users defined in users.xml or LDAP",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID identify the data source",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return the user with this name",
+				Optional:            true,
+			},
+			"pattern": schema.StringAttribute{
+				MarkdownDescription: "Only return users whose name matches this LIKE pattern, ie. `etl_%`",
+				Optional:            true,
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "Users ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "User name",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "User UUID",
+							Computed:            true,
+						},
+						"storage": schema.StringAttribute{
+							MarkdownDescription: "Access storage the user is defined in, ie. local_directory, users_xml or ldap",
+							Computed:            true,
+						},
+						"auth_type": schema.StringAttribute{
+							MarkdownDescription: "Authentication method",
+							Computed:            true,
+						},
+						"host_ip": schema.ListAttribute{
+							MarkdownDescription: "IP addresses and subnets the user can connect from",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"host_names": schema.ListAttribute{
+							MarkdownDescription: "Host names the user can connect from",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"default_roles_all": schema.BoolAttribute{
+							MarkdownDescription: "Whether every granted role is a default role",
+							Computed:            true,
+						},
+						"default_roles": schema.ListAttribute{
+							MarkdownDescription: "Default roles of the user",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"default_database": schema.StringAttribute{
+							MarkdownDescription: "Default database of the user, empty when not set",
+							Computed:            true,
+						},
+						"grantees_any": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user can grant privileges to anyone",
+							Computed:            true,
+						},
+						"grantees": schema.ListAttribute{
+							MarkdownDescription: "Users or roles the user can grant privileges to",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(clickhouse.Conn)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected clickhouse.Conn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+const readUsersDataQuery = `
+SELECT name, toString(id), storage, toString(auth_type), host_ip, host_names, toBool(default_roles_all), default_roles_list,
+	default_database, toBool(grantees_any), grantees_list
+FROM system.users`
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, args := filterQuery(readUsersDataQuery,
+		queryFilter{Condition: "name = ?", Value: data.Name},
+		queryFilter{Condition: "name LIKE ?", Value: data.Pattern},
+	)
+	rows, err := d.db.Query(ctx, query+" ORDER BY name", args...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Users",
+			"Could not read system.users, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	defer rows.Close()
+
+	data.Users = nil
+	for rows.Next() {
+		var name, id, storage, authType, defaultDatabase string
+		var hostIP, hostNames, defaultRoles, grantees []string
+		var defaultRolesAll, granteesAny bool
+		if err := rows.Scan(&name, &id, &storage, &authType, &hostIP, &hostNames, &defaultRolesAll, &defaultRoles,
+			&defaultDatabase, &granteesAny, &grantees); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Clickhouse Users",
+				"Could not retrieve Rows: unexpected error: "+err.Error(),
+			)
+			return
+		}
+		data.Users = append(data.Users, UserDataModel{
+			Name:            types.StringValue(name),
+			Id:              types.StringValue(id),
+			Storage:         types.StringValue(storage),
+			AuthType:        types.StringValue(authType),
+			HostIP:          stringValues(hostIP),
+			HostNames:       stringValues(hostNames),
+			DefaultRolesAll: types.BoolValue(defaultRolesAll),
+			DefaultRoles:    stringValues(defaultRoles),
+			DefaultDatabase: types.StringValue(defaultDatabase),
+			GranteesAny:     types.BoolValue(granteesAny),
+			Grantees:        stringValues(grantees),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Clickhouse Users",
+			"Could not retrieve Rows: unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(data.Name.ValueString() + ":" + data.Pattern.ValueString())
+
+	tflog.Trace(ctx, "read users data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
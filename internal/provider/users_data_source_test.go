@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUsersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUsersDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.clickhouseops_users.by_name", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_users.by_name", "users.0.name", "lookup_user"),
+					resource.TestCheckResourceAttr("data.clickhouseops_users.by_name", "users.0.storage", "local_directory"),
+					resource.TestCheckResourceAttr("data.clickhouseops_users.by_pattern", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.clickhouseops_users.by_pattern", "users.0.name", "lookup_user"),
+				),
+			},
+		},
+	})
+}
+
+const testAccUsersDataSourceConfig = `
+resource "clickhouseops_simpleuser" "lookup_user" {
+  name = "lookup_user"
+  sha256_password = sha256("dummy_password")
+}
+
+data "clickhouseops_users" "by_name" {
+  name = clickhouseops_simpleuser.lookup_user.name
+}
+
+data "clickhouseops_users" "by_pattern" {
+  pattern = "lookup_%"
+  depends_on = [clickhouseops_simpleuser.lookup_user]
+}
+`
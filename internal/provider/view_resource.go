@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -19,9 +21,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &ViewResource{}
-	_ resource.ResourceWithConfigure   = &ViewResource{}
-	_ resource.ResourceWithImportState = &ViewResource{}
+	_ resource.Resource                   = &ViewResource{}
+	_ resource.ResourceWithConfigure      = &ViewResource{}
+	_ resource.ResourceWithImportState    = &ViewResource{}
+	_ resource.ResourceWithValidateConfig = &ViewResource{}
+	_ resource.ResourceWithModifyPlan     = &ViewResource{}
 )
 
 func NewViewResource() resource.Resource {
@@ -29,7 +33,8 @@ func NewViewResource() resource.Resource {
 }
 
 type ViewResource struct {
-	db clickhouse.Conn
+	db     clickhouse.Conn
+	server *ServerInfo
 }
 
 type ViewResourceModel struct {
@@ -38,6 +43,9 @@ type ViewResourceModel struct {
 	DatabaseName types.String `tfsdk:"database_name"`
 	ClusterName  types.String `tfsdk:"cluster_name"`
 	SQL          types.String `tfsdk:"sql"`
+	Definer      types.String `tfsdk:"definer"`
+	SQLSecurity  types.String `tfsdk:"sql_security"`
+	Comment      types.String `tfsdk:"comment"`
 }
 
 func (r *ViewResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,6 +88,18 @@ func (r *ViewResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Clickhouse database comment",
 				Required:            true,
 			},
+			"definer": schema.StringAttribute{
+				MarkdownDescription: "User used to execute the view query when `sql_security` is `DEFINER`, ie. a username or `CURRENT_USER`",
+				Optional:            true,
+			},
+			"sql_security": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse view SQL security, one of `DEFINER`, `INVOKER` or `NONE`",
+				Optional:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "Clickhouse view comment",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -101,6 +121,138 @@ func (r *ViewResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	}
 
 	r.db = db
+	r.server = serverInfo(req.ProviderData)
+}
+
+// ModifyPlan fails the plan when definer or sql_security is set and the server does not support them.
+func (r *ViewResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ViewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Definer.IsNull() || !data.SQLSecurity.IsNull() {
+		resp.Diagnostics.Append(r.server.checkFeature(featureSQLSecurity)...)
+	}
+}
+
+func (r *ViewResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ViewResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.SQLSecurity.IsNull() && !data.SQLSecurity.IsUnknown() {
+		switch strings.ToUpper(data.SQLSecurity.ValueString()) {
+		case "DEFINER", "INVOKER", "NONE":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sql_security"),
+				"Invalid Attribute Configuration",
+				"Expect sql_security to be one of DEFINER, INVOKER or NONE, got: "+data.SQLSecurity.ValueString(),
+			)
+		}
+	}
+
+	if !data.SQL.IsUnknown() {
+		for _, err := range validateViewParameters(data.SQL.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("sql"), "Invalid Parameterized View", err)
+		}
+	}
+}
+
+/*
+	Clickhouse View Syntax for reference
+
+CREATE [OR REPLACE] VIEW [IF NOT EXISTS] [db.]table_name [ON CLUSTER cluster_name]
+[DEFINER = { user | CURRENT_USER }] [SQL SECURITY { DEFINER | INVOKER | NONE }]
+AS SELECT ...
+[COMMENT 'comment']
+*/
+const ddlCreateViewTemplate = `
+CREATE OR REPLACE VIEW "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}" {{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}}
+{{if not .Definer.IsNull}}DEFINER = {{definer .Definer.ValueString}}{{end}}
+{{if not .SQLSecurity.IsNull}}SQL SECURITY {{.SQLSecurity.ValueString}}{{end}}
+AS {{.SQL.ValueString}}
+{{if not .Comment.IsNull}}COMMENT '{{escape .Comment.ValueString}}'{{end}}
+`
+
+// viewParameterRegexp matches query parameters as {name:Type}, the type is optional so missing types can be reported.
+var viewParameterRegexp = regexp.MustCompile(`\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*(:([^{}]*))?\}`)
+
+// validateViewParameters checks the {name:Type} placeholders of a parameterized view query.
+func validateViewParameters(sql string) []string {
+	var errs []string
+	declared := map[string]string{}
+	for _, match := range viewParameterRegexp.FindAllStringSubmatch(stripLiterals(sql), -1) {
+		name, paramType := match[1], strings.TrimSpace(match[3])
+		switch {
+		case match[2] == "":
+			errs = append(errs, fmt.Sprintf("Parameter {%s} is missing its type, expected {%s:Type}", name, name))
+		case paramType == "":
+			errs = append(errs, fmt.Sprintf("Parameter {%s:} has an empty type, expected {%s:Type}", name, name))
+		case declared[name] != "" && declared[name] != paramType:
+			errs = append(errs, fmt.Sprintf("Parameter %s is declared with different types: %s and %s", name, declared[name], paramType))
+		default:
+			declared[name] = paramType
+		}
+	}
+	return errs
+}
+
+// stripLiterals blanks out quoted literals, identifiers and comments of a query, so that their content is not
+// mistaken for query parameters.
+func stripLiterals(sql string) string {
+	var stripped strings.Builder
+	var quote rune
+	comment, escaped := "", false
+	runes := []rune(sql)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		switch {
+		case comment == "--":
+			if c == '\n' {
+				comment = ""
+				stripped.WriteRune(c)
+			}
+		case comment == "/*":
+			if c == '*' && next == '/' {
+				comment = ""
+				i++
+			}
+		case quote != 0:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+				stripped.WriteRune(c)
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			stripped.WriteRune(c)
+		case c == '-' && next == '-', c == '/' && next == '*':
+			comment = string([]rune{c, next})
+			i++
+			stripped.WriteRune(' ')
+		default:
+			stripped.WriteRune(c)
+		}
+	}
+	return stripped.String()
 }
 
 func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -111,8 +263,7 @@ func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	queryTemplate := `CREATE OR REPLACE VIEW "{{.DatabaseName.ValueString}}"."{{.Name.ValueString}}" {{if not .ClusterName.IsNull}} ON CLUSTER '{{.ClusterName.ValueString}}'{{end}} AS {{.SQL.ValueString}}`
-	query, err := common.RenderTemplate(queryTemplate, data)
+	query, err := common.RenderTemplate(ddlCreateViewTemplate, data)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Clickhouse View",
@@ -164,6 +315,24 @@ func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	query, err := common.RenderTemplate(ddlCreateViewTemplate, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse View",
+			"Could not render DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.db.Exec(ctx, *query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Clickhouse View",
+			"Could not execute DDL, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -38,3 +39,47 @@ EOT
 		},
 	})
 }
+
+func TestAccParameterizedViewResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Plan time validation of query parameters
+			{
+				Config:      providerConfig + testAccParameterizedViewInvalidConfig,
+				ExpectError: regexp.MustCompile("is missing its type"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccParameterizedViewConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("clickhouseops_view.parameterized_view", "sql_security", "INVOKER"),
+					resource.TestCheckResourceAttr("clickhouseops_view.parameterized_view", "comment", "parameterized view"),
+				),
+			},
+		},
+	})
+}
+
+const (
+	testAccParameterizedViewInvalidConfig = `
+resource "clickhouseops_view" "parameterized_view" {
+	name = "parameterized_view"
+	database_name = "default"
+	sql = <<EOT
+SELECT number FROM system.numbers WHERE number = {id}
+EOT
+}
+`
+	testAccParameterizedViewConfig = `
+resource "clickhouseops_view" "parameterized_view" {
+	name = "parameterized_view"
+	database_name = "default"
+	sql_security = "INVOKER"
+	comment = "parameterized view"
+	sql = <<EOT
+SELECT number FROM system.numbers WHERE number = {id:UInt64} LIMIT 1
+EOT
+}
+`
+)